@@ -14,20 +14,38 @@ type Client struct {
 	Username    string
 	Data        map[string]interface{} // Additional data if needed
 	Conn        *websocket.Conn
+	Encoding    string // 메시지 인코딩 (EncodingJSON 또는 EncodingProtobuf, 비어있으면 JSON)
 	Syncing     bool
 	syncLock    sync.Mutex
+	writeLock   sync.Mutex // 여러 심볼의 매칭 엔진이 동시에 전송하므로 쓰기 직렬화
 	pendingMsgs []PendingMessage
 }
 
+// WriteMessage 동시 쓰기로부터 안전하게 메시지 전송
+func (client *Client) WriteMessage(messageType int, data []byte) error {
+	client.writeLock.Lock()
+	defer client.writeLock.Unlock()
+	return client.Conn.WriteMessage(messageType, data)
+}
+
+// WritePayload 연결의 인코딩으로 메시지 전송 (직렬화에 실패한 메시지는 생략)
+func (client *Client) WritePayload(payload *Payload) error {
+	messageType, data := payload.Encode(client.Encoding)
+	if data == nil {
+		return nil
+	}
+	return client.WriteMessage(messageType, data)
+}
+
 type PendingMessage struct {
-	ID          int
-	MessageType int
-	Data        []byte
+	ID      int
+	Payload *Payload
 }
 
 type WSHub struct {
 	clients           sync.Map
 	messages          []Message
+	subscribers       map[chan Message]int // WebSocket 이외의 구독자 (gRPC 스트림 등) 별 사용자 ID (0이면 전체 메시지만)
 	lock              sync.Mutex
 	AllowMultiConnect bool // true: 여러개 허용, false: 한개만 허용
 }
@@ -35,7 +53,7 @@ type WSHub struct {
 type Message struct {
 	ID        int
 	Timestamp int64
-	Data      []byte
+	Payload   *Payload
 }
 
 func NewWSHub(multiConnection bool) *WSHub {
@@ -63,12 +81,73 @@ func (hub *WSHub) RegisterClient(client *Client) {
 	connMap.Store(client.ConnID, client)
 }
 
+// Subscribe WebSocket 이외의 구독자 등록 (userID 가 0이 아니면 해당 사용자에게 보낸 메시지도 수신)
+// since 이후 보관된 메시지를 먼저 채운 채널과 해제 함수 반환 (since 가 -1 이면 새 메시지만), 구독자가 메시지를 제때 읽지 않아 채널이 가득 차면 채널을 닫음
+func (hub *WSHub) Subscribe(userID int, since int64, buffer int) (<-chan Message, func()) {
+	hub.lock.Lock()
+	defer hub.lock.Unlock()
+
+	var backlog []Message
+	if since != -1 {
+		for _, msg := range hub.messages {
+			if (msg.ID == 0 || msg.ID == userID) && msg.Timestamp > since {
+				backlog = append(backlog, msg)
+			}
+		}
+	}
+	ch := make(chan Message, buffer+len(backlog))
+	for _, msg := range backlog {
+		ch <- msg
+	}
+	if hub.subscribers == nil {
+		hub.subscribers = make(map[chan Message]int)
+	}
+	hub.subscribers[ch] = userID
+
+	return ch, func() {
+		hub.lock.Lock()
+		defer hub.lock.Unlock()
+		if _, ok := hub.subscribers[ch]; ok {
+			delete(hub.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publish 구독자에게 메시지 전달 (lock 을 잡은 상태에서 호출할 것)
+func (hub *WSHub) publish(msg Message) {
+	for ch, userID := range hub.subscribers {
+		if msg.ID != 0 && msg.ID != userID {
+			continue
+		}
+		select {
+		case ch <- msg:
+		default:
+			log.Error("구독자 대기열 초과로 구독 해제")
+			delete(hub.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// closeSubscribers 모든 구독 해제 (lock 을 잡은 상태에서 호출할 것)
+func (hub *WSHub) closeSubscribers() {
+	for ch := range hub.subscribers {
+		delete(hub.subscribers, ch)
+		close(ch)
+	}
+}
+
 func (hub *WSHub) DisconnectAll() {
+	hub.lock.Lock()
+	hub.closeSubscribers()
+	hub.lock.Unlock()
+
 	hub.clients.Range(func(_, v interface{}) bool {
 		connMap := v.(*sync.Map)
 		connMap.Range(func(_, v interface{}) bool {
 			client := v.(*Client)
-			err := client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+			err := client.WriteMessage(websocket.CloseMessage, []byte{})
 			if err != nil {
 				return false
 			}
@@ -99,6 +178,12 @@ func (hub *WSHub) UnregisterClient(client *Client) {
 	}
 }
 
+// HasClient 사용자의 연결이 하나라도 남아 있는지 확인
+func (hub *WSHub) HasClient(userID int) bool {
+	_, ok := hub.clients.Load(userID)
+	return ok
+}
+
 func (hub *WSHub) GetClient(userID int, connID string) (*Client, bool) {
 	if conns, ok := hub.clients.Load(userID); ok {
 		connMap := conns.(*sync.Map)
@@ -109,13 +194,15 @@ func (hub *WSHub) GetClient(userID int, connID string) (*Client, bool) {
 	return nil, false
 }
 
-func (hub *WSHub) BroadcastMessage(timestamp int64, messageType int, message []byte) {
+func (hub *WSHub) BroadcastMessage(timestamp int64, payload *Payload) {
 	hub.lock.Lock()
-	hub.messages = append(hub.messages, Message{
+	msg := Message{
 		ID:        0,
 		Timestamp: timestamp,
-		Data:      message,
-	})
+		Payload:   payload,
+	}
+	hub.messages = append(hub.messages, msg)
+	hub.publish(msg)
 	hub.lock.Unlock()
 
 	hub.clients.Range(func(key, value interface{}) bool {
@@ -127,16 +214,15 @@ func (hub *WSHub) BroadcastMessage(timestamp int64, messageType int, message []b
 			client.syncLock.Lock()
 			if client.Syncing {
 				client.pendingMsgs = append(client.pendingMsgs, PendingMessage{
-					ID:          0,
-					MessageType: messageType,
-					Data:        message,
+					ID:      0,
+					Payload: payload,
 				})
 				client.syncLock.Unlock()
 				return true
 			}
 			client.syncLock.Unlock()
 
-			err := client.Conn.WriteMessage(messageType, message)
+			err := client.WritePayload(payload)
 			if err != nil {
 				log.Error("WebSocket 전송 오류:", err)
 				hub.UnregisterClient(client)
@@ -152,13 +238,15 @@ func (hub *WSHub) BroadcastMessage(timestamp int64, messageType int, message []b
 	})
 }
 
-func (hub *WSHub) SendMessageToUser(userID int, timestamp int64, messageType int, message []byte) {
+func (hub *WSHub) SendMessageToUser(userID int, timestamp int64, payload *Payload) {
 	hub.lock.Lock()
-	hub.messages = append(hub.messages, Message{
+	msg := Message{
 		ID:        userID,
 		Timestamp: timestamp,
-		Data:      message,
-	})
+		Payload:   payload,
+	}
+	hub.messages = append(hub.messages, msg)
+	hub.publish(msg)
 	hub.lock.Unlock()
 
 	if conns, ok := hub.clients.Load(userID); ok {
@@ -170,16 +258,15 @@ func (hub *WSHub) SendMessageToUser(userID int, timestamp int64, messageType int
 			client.syncLock.Lock()
 			if client.Syncing {
 				client.pendingMsgs = append(client.pendingMsgs, PendingMessage{
-					ID:          userID,
-					MessageType: messageType,
-					Data:        message,
+					ID:      userID,
+					Payload: payload,
 				})
 				client.syncLock.Unlock()
 				return true
 			}
 			client.syncLock.Unlock()
 
-			err := client.Conn.WriteMessage(messageType, message)
+			err := client.WritePayload(payload)
 			if err != nil {
 				log.Error("WebSocket 전송 오류:", err)
 				hub.UnregisterClient(client)
@@ -220,7 +307,7 @@ func (hub *WSHub) SendMessageToUserSince(client *Client, since string) {
 
 	for _, msg := range messages {
 		if (msg.ID == 0 || msg.ID == client.ID) && msg.Timestamp > sinceInt {
-			err := client.Conn.WriteMessage(websocket.TextMessage, msg.Data)
+			err := client.WritePayload(msg.Payload)
 			if err != nil {
 				log.Error("WebSocket 전송 오류:", err)
 				return
@@ -235,7 +322,7 @@ func (hub *WSHub) SendMessageToUserSince(client *Client, since string) {
 		// 동기화 중 대기된 메시지들 전송
 		for _, pendingMsg := range client.pendingMsgs {
 			if pendingMsg.ID == 0 || pendingMsg.ID == client.ID {
-				err := client.Conn.WriteMessage(pendingMsg.MessageType, pendingMsg.Data)
+				err := client.WritePayload(pendingMsg.Payload)
 				if err != nil {
 					log.Error("대기된 메시지 전송 오류:", err)
 					break
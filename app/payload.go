@@ -0,0 +1,55 @@
+package app
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/websocket/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	EncodingJSON     = "json"     // 텍스트 프레임 (기본값)
+	EncodingProtobuf = "protobuf" // 바이너리 프레임
+)
+
+// Payload 이벤트 하나의 인코딩별 메시지 (연결마다 직렬화하지 않도록 인코딩별로 처음 필요할 때 한 번만 직렬화)
+type Payload struct {
+	Value   any                  // 원본 이벤트 (template 구조체)
+	toProto func() proto.Message // protobuf 메시지 변환 (nil 이면 protobuf 연결에도 JSON 전송)
+
+	jsonOnce  sync.Once
+	jsonData  []byte
+	protoOnce sync.Once
+	protoData []byte
+}
+
+func NewPayload(value any, toProto func() proto.Message) *Payload {
+	return &Payload{Value: value, toProto: toProto}
+}
+
+// Encode 인코딩에 맞는 WebSocket 메시지 종류와 데이터 반환 (직렬화에 실패하면 데이터는 nil)
+func (p *Payload) Encode(encoding string) (int, []byte) {
+	if encoding == EncodingProtobuf && p.toProto != nil {
+		p.protoOnce.Do(func() {
+			data, err := proto.Marshal(p.toProto())
+			if err != nil {
+				log.Error("protobuf 직렬화 오류:", err)
+				return
+			}
+			p.protoData = data
+		})
+		return websocket.BinaryMessage, p.protoData
+	}
+
+	p.jsonOnce.Do(func() {
+		data, err := json.Marshal(p.Value)
+		if err != nil {
+			log.Error("JSON 직렬화 오류:", err)
+			return
+		}
+		p.jsonData = data
+	})
+	return websocket.TextMessage, p.jsonData
+}
@@ -18,6 +18,8 @@ type Repositories struct {
 	Symbol     *postgresql.SymbolDBRepository
 	APIKey     *postgresql.APIKeyDBRepository
 	AcceptCode *postgresql.AcceptCodeDBRepository
+	Trade      *postgresql.TradeDBRepository
+	OrderEvent *postgresql.OrderEventDBRepository
 }
 
 var (
@@ -44,12 +46,16 @@ func initializeApp() *App {
 	userRepo := postgresql.NewUserRepository(postgresDB, acceptRepo)
 	symbolRepo := postgresql.NewSymbolRepository(postgresDB)
 	apikeyRepo := postgresql.NewAPIKeyRepository(postgresDB)
+	tradeRepo := postgresql.NewTradeRepository(postgresDB)
+	orderEventRepo := postgresql.NewOrderEventRepository(postgresDB)
 
 	repos := &Repositories{
 		AcceptCode: acceptRepo,
 		User:       userRepo,
 		Symbol:     symbolRepo,
 		APIKey:     apikeyRepo,
+		Trade:      tradeRepo,
+		OrderEvent: orderEventRepo,
 	}
 
 	if err := createTables(ctx, repos); err != nil {
@@ -75,12 +81,22 @@ func createTables(ctx context.Context, repos *Repositories) error {
 	if err := repos.AcceptCode.CreateAcceptCodesTable(ctx); err != nil {
 		return err
 	}
+	if err := repos.Trade.CreateTradesTable(ctx); err != nil {
+		return err
+	}
+	if err := repos.OrderEvent.CreateOrderEventsTable(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (app *App) UserRepo() *postgresql.UserDBRepository     { return app.Repositories.User }
 func (app *App) SymbolRepo() *postgresql.SymbolDBRepository { return app.Repositories.Symbol }
 func (app *App) APIKeyRepo() *postgresql.APIKeyDBRepository { return app.Repositories.APIKey }
+func (app *App) TradeRepo() *postgresql.TradeDBRepository   { return app.Repositories.Trade }
+func (app *App) OrderEventRepo() *postgresql.OrderEventDBRepository {
+	return app.Repositories.OrderEvent
+}
 func (app *App) AcceptCodeRepo() *postgresql.AcceptCodeDBRepository {
 	return app.Repositories.AcceptCode
 }
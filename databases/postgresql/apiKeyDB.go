@@ -86,10 +86,12 @@ type APIKey struct {
 	AllowedCuntries   []string    `json:"allowed_countries,omitempty"`
 	AllowedUserAgents []string    `json:"allowed_user_agents,omitempty"`
 	Status            string      `json:"status"`
-	CreatedAt         time.Time   `json:"created_at"`
-	LastUsed          *time.Time  `json:"last_used"`
-	ExpiresAt         *time.Time  `json:"expires_at"`
-	UpdatedAt         time.Time   `json:"updated_at"`
+	// CancelOnDisconnect 이 키로 연결한 마지막 알림 WebSocket이 끊기고 유예 시간 안에 재접속하지 않으면 미체결 주문 전체 취소
+	CancelOnDisconnect bool       `json:"cancel_on_disconnect"`
+	CreatedAt          time.Time  `json:"created_at"`
+	LastUsed           *time.Time `json:"last_used"`
+	ExpiresAt          *time.Time `json:"expires_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 type APIKeyRepository interface {
@@ -101,6 +103,7 @@ type APIKeyRepository interface {
 	UpdateAPIKeyStatus(ctx context.Context, keyID, status string) error
 	UpdateLastUsed(ctx context.Context, keyID string) error
 	RevokeAPIKey(ctx context.Context, keyID string) error
+	SetCancelOnDisconnect(ctx context.Context, keyID string, enabled bool) error
 	CleanupExpiredKeys(ctx context.Context) error
 }
 
@@ -131,6 +134,7 @@ func (r *APIKeyDBRepository) CreateAPIKeysTable(ctx context.Context) error {
 	    allowed_countries JSONB DEFAULT '["*"]',
 	    allowed_user_agents JSONB DEFAULT '["*"]',
 		status VARCHAR(20) DEFAULT 'active',
+		cancel_on_disconnect BOOLEAN NOT NULL DEFAULT FALSE,
 		created_at TIMESTAMPTZ DEFAULT NOW(),
 		last_used TIMESTAMPTZ,
 		expires_at TIMESTAMPTZ,
@@ -141,6 +145,9 @@ func (r *APIKeyDBRepository) CreateAPIKeysTable(ctx context.Context) error {
 	CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
 	CREATE INDEX IF NOT EXISTS idx_api_keys_status ON api_keys(status);
 	CREATE INDEX IF NOT EXISTS idx_api_keys_expires ON api_keys(expires_at);
+
+	-- 기존 테이블에 추가된 컬럼
+	ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS cancel_on_disconnect BOOLEAN NOT NULL DEFAULT FALSE;
 	`
 	_, err := r.db.GetPool().Exec(ctx, query)
 	return err
@@ -331,7 +338,7 @@ func (r *APIKeyDBRepository) AuthenticateAPIKey(ctx context.Context, apiKey stri
 
 	// 데이터베이스에서 해당 prefix를 가진 활성 키들 조회
 	query := `
-		SELECT id, user_id, key_hash, key_prefix, name, scopes, status, created_at, last_used, expires_at, updated_at
+		SELECT id, user_id, key_hash, key_prefix, name, scopes, status, cancel_on_disconnect, created_at, last_used, expires_at, updated_at
 		FROM api_keys 
 		WHERE key_prefix = $1 AND status = $2 AND (expires_at IS NULL OR expires_at > NOW())
 	`
@@ -347,7 +354,7 @@ func (r *APIKeyDBRepository) AuthenticateAPIKey(ctx context.Context, apiKey stri
 		var key APIKey
 		err := rows.Scan(
 			&key.ID, &key.UserID, &key.KeyHash, &key.KeyPrefix,
-			&key.Name, &key.Scopes, &key.Status, &key.CreatedAt,
+			&key.Name, &key.Scopes, &key.Status, &key.CancelOnDisconnect, &key.CreatedAt,
 			&key.LastUsed, &key.ExpiresAt, &key.UpdatedAt,
 		)
 		if err != nil {
@@ -371,7 +378,7 @@ func (r *APIKeyDBRepository) AuthenticateAPIKey(ctx context.Context, apiKey stri
 
 func (r *APIKeyDBRepository) GetUserAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
 	query := `
-		SELECT id, user_id, key_prefix, name, scopes, status, created_at, last_used, expires_at, updated_at
+		SELECT id, user_id, key_prefix, name, scopes, status, cancel_on_disconnect, created_at, last_used, expires_at, updated_at
 		FROM api_keys 
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -388,7 +395,7 @@ func (r *APIKeyDBRepository) GetUserAPIKeys(ctx context.Context, userID string)
 		var key APIKey
 		err := rows.Scan(
 			&key.ID, &key.UserID, &key.KeyPrefix, &key.Name,
-			&key.Scopes, &key.Status, &key.CreatedAt,
+			&key.Scopes, &key.Status, &key.CancelOnDisconnect, &key.CreatedAt,
 			&key.LastUsed, &key.ExpiresAt, &key.UpdatedAt,
 		)
 		if err != nil {
@@ -402,7 +409,7 @@ func (r *APIKeyDBRepository) GetUserAPIKeys(ctx context.Context, userID string)
 
 func (r *APIKeyDBRepository) GetAPIKeyByID(ctx context.Context, keyID string) (*APIKey, error) {
 	query := `
-		SELECT id, user_id, key_prefix, name, scopes, status, created_at, last_used, expires_at, updated_at
+		SELECT id, user_id, key_prefix, name, scopes, status, cancel_on_disconnect, created_at, last_used, expires_at, updated_at
 		FROM api_keys 
 		WHERE id = $1
 	`
@@ -410,7 +417,7 @@ func (r *APIKeyDBRepository) GetAPIKeyByID(ctx context.Context, keyID string) (*
 	var key APIKey
 	err := r.db.GetPool().QueryRow(ctx, query, keyID).Scan(
 		&key.ID, &key.UserID, &key.KeyPrefix, &key.Name,
-		&key.Scopes, &key.Status, &key.CreatedAt,
+		&key.Scopes, &key.Status, &key.CancelOnDisconnect, &key.CreatedAt,
 		&key.LastUsed, &key.ExpiresAt, &key.UpdatedAt,
 	)
 
@@ -452,6 +459,20 @@ func (r *APIKeyDBRepository) RevokeAPIKey(ctx context.Context, keyID string) err
 	return r.UpdateAPIKeyStatus(ctx, keyID, APIKeyStatusRevoked)
 }
 
+// SetCancelOnDisconnect 연결 종료 시 주문 자동 취소 설정 변경
+func (r *APIKeyDBRepository) SetCancelOnDisconnect(ctx context.Context, keyID string, enabled bool) error {
+	query := `
+		UPDATE api_keys 
+		SET cancel_on_disconnect = $1, updated_at = $2 
+		WHERE id = $3
+	`
+	_, err := r.db.GetPool().Exec(ctx, query, enabled, time.Now().UTC(), keyID)
+	if err != nil {
+		return fmt.Errorf("연결 종료 시 주문 취소 설정 업데이트 실패: %w", err)
+	}
+	return nil
+}
+
 func (r *APIKeyDBRepository) CleanupExpiredKeys(ctx context.Context) error {
 	query := `
 		UPDATE api_keys 
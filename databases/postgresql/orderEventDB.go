@@ -0,0 +1,243 @@
+package postgresql
+
+import (
+	"PJS_Exchange/databases"
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OrderEvent 저장된 실행 보고서 (template.ExecutionReport)
+type OrderEvent struct {
+	ID             int64     `json:"id"`
+	Seq            int64     `json:"seq"`
+	Timestamp      time.Time `json:"timestamp"`
+	UserID         int       `json:"-"`
+	ExecType       string    `json:"exec_type"`
+	ExecutionID    string    `json:"execution_id,omitempty"`
+	OrderID        string    `json:"order_id"`
+	ClientOrderID  string    `json:"client_order_id,omitempty"`
+	Symbol         string    `json:"symbol"`
+	Side           string    `json:"side"` // "buy" or "sell"
+	OrderType      string    `json:"type"` // "limit", "market", "stop" or "stop_limit"
+	Status         string    `json:"status"`
+	Price          int64     `json:"price"`
+	StopPrice      int64     `json:"stop_price,omitempty"`
+	TimeInForce    string    `json:"time_in_force,omitempty"`
+	Quantity       int       `json:"quantity"`
+	LastPrice      int64     `json:"last_price"`
+	LastQuantity   int       `json:"last_quantity"`
+	CumQuantity    int       `json:"cum_quantity"`
+	LeavesQuantity int       `json:"leaves_quantity"`
+	AvgPrice       float64   `json:"avg_price"`
+	Reason         string    `json:"reason,omitempty"`
+	ReasonCode     string    `json:"reason_code,omitempty"`
+}
+
+// HistoryFilter 주문 내역 / 체결 내역 조회 조건 (빈 값은 조건에서 제외)
+type HistoryFilter struct {
+	UserID        int
+	Symbol        string
+	Side          string
+	Status        string
+	ClientOrderID string // 주문 내역만
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+	Offset        int
+}
+
+type OrderEventRepository interface {
+	CreateOrderEventsTable(ctx context.Context) error
+	InsertOrderEvents(ctx context.Context, events []OrderEvent) error
+	GetOrderEvents(ctx context.Context, filter HistoryFilter) ([]OrderEvent, error)
+	GetLastSeqs(ctx context.Context) (map[int]int64, error)
+	ReserveSeq(ctx context.Context, userID int, seq int64) error
+}
+
+type OrderEventDBRepository struct {
+	db *databases.PostgresDBPool
+}
+
+func NewOrderEventRepository(db *databases.PostgresDBPool) *OrderEventDBRepository {
+	return &OrderEventDBRepository{db: db}
+}
+
+// CreateOrderEventsTable order_events 테이블을 생성합니다. (TimescaleDB가 설치되어 있으면 하이퍼테이블로 생성)
+func (r *OrderEventDBRepository) CreateOrderEventsTable(ctx context.Context) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS order_events (
+		id BIGSERIAL NOT NULL,
+		seq BIGINT NOT NULL,
+		timestamp TIMESTAMPTZ NOT NULL,
+		user_id INT NOT NULL,
+		exec_type VARCHAR(10) NOT NULL,
+		execution_id VARCHAR(36) DEFAULT '',
+		order_id VARCHAR(36) NOT NULL,
+		client_order_id VARCHAR(64) DEFAULT '',
+		symbol VARCHAR(20) NOT NULL,
+		side VARCHAR(4) NOT NULL,
+		order_type VARCHAR(10) NOT NULL,
+		status VARCHAR(20) NOT NULL,
+		price BIGINT NOT NULL,
+		stop_price BIGINT NOT NULL DEFAULT 0,
+		time_in_force VARCHAR(3) DEFAULT '',
+		quantity INT NOT NULL,
+		last_price BIGINT NOT NULL DEFAULT 0,
+		last_quantity INT NOT NULL DEFAULT 0,
+		cum_quantity INT NOT NULL DEFAULT 0,
+		leaves_quantity INT NOT NULL DEFAULT 0,
+		avg_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+		reason TEXT DEFAULT '',
+		reason_code VARCHAR(30) DEFAULT '',
+		PRIMARY KEY (id, timestamp)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_order_events_user_timestamp ON order_events(user_id, timestamp);
+	CREATE INDEX IF NOT EXISTS idx_order_events_order_id ON order_events(order_id);
+	CREATE INDEX IF NOT EXISTS idx_order_events_client_order_id ON order_events(user_id, client_order_id);
+	-- 사용자별 알림 순번은 고유 (저장 후 응답을 받지 못해 다시 저장해도 중복되지 않도록, 하이퍼테이블이므로 timestamp 포함)
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_order_events_user_seq ON order_events(user_id, seq, timestamp);
+
+	-- 사용자별로 미리 예약한 실행 보고서 번호 (주문 이벤트는 비동기로 저장되므로 재시작 후 보낸 번호를 다시 쓰지 않도록)
+	CREATE TABLE IF NOT EXISTS order_event_seqs (
+		user_id INT PRIMARY KEY,
+		reserved BIGINT NOT NULL
+	);
+	`
+	_, err := r.db.GetPool().Exec(ctx, query)
+	if err != nil {
+		log.Println("Failed to create order_events table:", err)
+		return err
+	}
+
+	var timescale bool
+	err = r.db.GetPool().QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'timescaledb')`).Scan(&timescale)
+	if err != nil {
+		return err
+	}
+	if timescale {
+		_, err = r.db.GetPool().Exec(ctx, `SELECT create_hypertable('order_events', 'timestamp', if_not_exists => TRUE, migrate_data => TRUE)`)
+		if err != nil {
+			log.Println("Failed to create order_events hypertable:", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertOrderEvents 주문 이벤트를 한번에 저장합니다. (이미 저장된 이벤트는 무시)
+func (r *OrderEventDBRepository) InsertOrderEvents(ctx context.Context, events []OrderEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO order_events (seq, timestamp, user_id, exec_type, execution_id, order_id, client_order_id, symbol, side, order_type, status,
+			price, stop_price, time_in_force, quantity, last_price, last_quantity, cum_quantity, leaves_quantity, avg_price, reason, reason_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+		ON CONFLICT DO NOTHING`
+
+	batch := &pgx.Batch{}
+	for _, event := range events {
+		batch.Queue(query,
+			event.Seq, event.Timestamp, event.UserID, event.ExecType, event.ExecutionID, event.OrderID, event.ClientOrderID, event.Symbol,
+			event.Side, event.OrderType, event.Status, event.Price, event.StopPrice, event.TimeInForce, event.Quantity, event.LastPrice, event.LastQuantity,
+			event.CumQuantity, event.LeavesQuantity, event.AvgPrice, event.Reason, event.ReasonCode)
+	}
+
+	return r.db.GetPool().SendBatch(ctx, batch).Close()
+}
+
+// GetOrderEvents 사용자의 주문 이벤트를 시간순으로 조회합니다.
+func (r *OrderEventDBRepository) GetOrderEvents(ctx context.Context, filter HistoryFilter) ([]OrderEvent, error) {
+	query := `
+		SELECT id, seq, timestamp, user_id, exec_type, execution_id, order_id, client_order_id, symbol, side, order_type, status,
+			price, stop_price, time_in_force, quantity, last_price, last_quantity, cum_quantity, leaves_quantity, avg_price, reason, reason_code
+		FROM order_events
+		WHERE user_id = $1`
+	args := []interface{}{filter.UserID}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += " AND " + condition + " $" + strconv.Itoa(len(args))
+	}
+	if filter.Symbol != "" {
+		addCondition("symbol =", filter.Symbol)
+	}
+	if filter.Side != "" {
+		addCondition("side =", filter.Side)
+	}
+	if filter.Status != "" {
+		addCondition("status =", filter.Status)
+	}
+	if filter.ClientOrderID != "" {
+		addCondition("client_order_id =", filter.ClientOrderID)
+	}
+	if filter.From != nil {
+		addCondition("timestamp >=", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("timestamp <", *filter.To)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query += " ORDER BY timestamp ASC, seq ASC LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	rows, err := r.db.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]OrderEvent, 0)
+	for rows.Next() {
+		var event OrderEvent
+		if err := rows.Scan(&event.ID, &event.Seq, &event.Timestamp, &event.UserID, &event.ExecType, &event.ExecutionID,
+			&event.OrderID, &event.ClientOrderID, &event.Symbol, &event.Side, &event.OrderType, &event.Status, &event.Price, &event.StopPrice, &event.TimeInForce, &event.Quantity,
+			&event.LastPrice, &event.LastQuantity, &event.CumQuantity, &event.LeavesQuantity, &event.AvgPrice,
+			&event.Reason, &event.ReasonCode); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// GetLastSeqs 사용자별 마지막 실행 보고서 번호를 조회합니다. (예약한 번호 포함)
+func (r *OrderEventDBRepository) GetLastSeqs(ctx context.Context) (map[int]int64, error) {
+	rows, err := r.db.GetPool().Query(ctx, `
+		SELECT user_id, MAX(seq) FROM (
+			SELECT user_id, seq FROM order_events
+			UNION ALL
+			SELECT user_id, reserved FROM order_event_seqs
+		) seqs
+		GROUP BY user_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seqs := make(map[int]int64)
+	for rows.Next() {
+		var userID int
+		var seq int64
+		if err := rows.Scan(&userID, &seq); err != nil {
+			return nil, err
+		}
+		seqs[userID] = seq
+	}
+	return seqs, rows.Err()
+}
+
+// ReserveSeq 사용자의 실행 보고서 번호를 seq 까지 예약합니다. (이미 더 큰 번호까지 예약되어 있으면 유지)
+func (r *OrderEventDBRepository) ReserveSeq(ctx context.Context, userID int, seq int64) error {
+	_, err := r.db.GetPool().Exec(ctx, `
+		INSERT INTO order_event_seqs (user_id, reserved) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET reserved = GREATEST(order_event_seqs.reserved, EXCLUDED.reserved)`,
+		userID, seq)
+	return err
+}
@@ -21,20 +21,22 @@ type Status struct {
 }
 
 type Symbol struct {
-	ID                   int             `json:"id"`
-	Symbol               string          `json:"symbol"`
-	Name                 string          `json:"name"`
-	Detail               string          `json:"detail"`
-	Url                  string          `json:"url"`
-	Logo                 string          `json:"logo"`
-	Market               string          `json:"market"`
-	Type                 string          `json:"type"` // "stock", "index" 등
-	MinimumOrderQuantity float32         `json:"minimum_order_quantity"`
-	TickSize             float32         `json:"tick_size"`
-	TotalStocks          int64           `json:"total_stocks"`
-	IPOPrice             float64         `json:"ipo_price,omitempty"`
-	Tags                 map[string]bool `json:"tags"`
-	Status               Status          `json:"status"`
+	ID                    int             `json:"id"`
+	Symbol                string          `json:"symbol"`
+	Name                  string          `json:"name"`
+	Detail                string          `json:"detail"`
+	Url                   string          `json:"url"`
+	Logo                  string          `json:"logo"`
+	Market                string          `json:"market"`
+	Type                  string          `json:"type"` // "stock", "index" 등
+	MinimumOrderQuantity  float32         `json:"minimum_order_quantity"`
+	TickSize              float32         `json:"tick_size"`
+	TotalStocks           int64           `json:"total_stocks"`
+	IPOPrice              float64         `json:"ipo_price,omitempty"`
+	PriceLimitPercent     float32         `json:"price_limit_percent"`     // 일일 가격 제한폭 (전일 종가 대비 %, 0이면 제한 없음)
+	VolatilityBandPercent float32         `json:"volatility_band_percent"` // 변동성 완화장치 발동 기준 (직전 체결가 대비 %, 0이면 사용 안함)
+	Tags                  map[string]bool `json:"tags"`
+	Status                Status          `json:"status"`
 }
 
 type SymbolRepository interface {
@@ -63,9 +65,17 @@ func (r *SymbolDBRepository) CreateSymbolsTable(ctx context.Context) error {
 		tick_size REAL DEFAULT 1,
 		total_stocks BIGINT DEFAULT 0,
 		ipo_price REAL DEFAULT 0,
+		price_limit_percent REAL DEFAULT 30,
+		volatility_band_percent REAL DEFAULT 5,
+		close_price BIGINT DEFAULT 0,
 		tags JSONB DEFAULT '{}'::jsonb,
 		status JSONB DEFAULT '{"status": "inactive", "reason": ""}'::jsonb
 	);
+
+	-- 기존 테이블에 추가된 컬럼
+	ALTER TABLE symbols ADD COLUMN IF NOT EXISTS price_limit_percent REAL DEFAULT 30;
+	ALTER TABLE symbols ADD COLUMN IF NOT EXISTS volatility_band_percent REAL DEFAULT 5;
+	ALTER TABLE symbols ADD COLUMN IF NOT EXISTS close_price BIGINT DEFAULT 0;
 	`
 	_, err := r.db.GetPool().Exec(ctx, query)
 	return err
@@ -107,7 +117,7 @@ func (r *SymbolDBRepository) SymbolListing(ctx context.Context, sym *Symbol) (*S
 }
 
 func (r *SymbolDBRepository) GetSymbols(ctx context.Context) (*[]Symbol, error) {
-	query := `SELECT id, symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, status FROM symbols`
+	query := `SELECT id, symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, price_limit_percent, volatility_band_percent, tags, status FROM symbols`
 	rows, err := r.db.GetPool().Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -121,7 +131,7 @@ func (r *SymbolDBRepository) GetSymbols(ctx context.Context) (*[]Symbol, error)
 		var statusJSON []byte
 
 		err = rows.Scan(&sym.ID, &sym.Symbol, &sym.Name, &sym.Detail, &sym.Url, &sym.Logo,
-			&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &statusJSON)
+			&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &sym.PriceLimitPercent, &sym.VolatilityBandPercent, &sym.Tags, &statusJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -142,7 +152,7 @@ func (r *SymbolDBRepository) GetSymbols(ctx context.Context) (*[]Symbol, error)
 }
 
 func (r *SymbolDBRepository) GetSymbolsViewable(ctx context.Context) (*[]Symbol, error) {
-	query := `SELECT  symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, status FROM symbols WHERE status->>'status' IN ($1, $2, $3)`
+	query := `SELECT  symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, price_limit_percent, volatility_band_percent, tags, status FROM symbols WHERE status->>'status' IN ($1, $2, $3)`
 	rows, err := r.db.GetPool().Query(ctx, query, StatusActive, StatusInactive, StatusSuspended)
 	if err != nil {
 		return nil, err
@@ -156,7 +166,7 @@ func (r *SymbolDBRepository) GetSymbolsViewable(ctx context.Context) (*[]Symbol,
 		var statusJSON []byte
 
 		err = rows.Scan(&sym.Symbol, &sym.Name, &sym.Detail, &sym.Url, &sym.Logo,
-			&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &statusJSON)
+			&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &sym.PriceLimitPercent, &sym.VolatilityBandPercent, &sym.Tags, &statusJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -177,13 +187,13 @@ func (r *SymbolDBRepository) GetSymbolsViewable(ctx context.Context) (*[]Symbol,
 }
 
 func (r *SymbolDBRepository) GetSymbolData(ctx context.Context, symbol string) (*Symbol, error) {
-	query := `SELECT id, symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, status FROM symbols WHERE symbol = $1`
+	query := `SELECT id, symbol, name, detail, url, logo, market, type, minimum_order_quantity, tick_size, total_stocks, ipo_price, price_limit_percent, volatility_band_percent, tags, status FROM symbols WHERE symbol = $1`
 	sym := &Symbol{}
 	var statusJSON []byte
 
 	err := r.db.GetPool().QueryRow(ctx, query, symbol).Scan(
 		&sym.ID, &sym.Symbol, &sym.Name, &sym.Detail, &sym.Url, &sym.Logo,
-		&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &statusJSON)
+		&sym.Market, &sym.Type, &sym.MinimumOrderQuantity, &sym.TickSize, &sym.TotalStocks, &sym.IPOPrice, &sym.PriceLimitPercent, &sym.VolatilityBandPercent, &sym.Tags, &statusJSON)
 
 	if err != nil {
 		return nil, err
@@ -201,7 +211,7 @@ func (r *SymbolDBRepository) IsSymbolExist(ctx context.Context, symbol string) (
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM symbols WHERE symbol = $1)`
 	_ = r.db.GetPool().QueryRow(ctx, query, symbol).Scan(&exists)
-	return exists, fmt.Errorf("Symbol '" + symbol + "' does not exist.")
+	return exists, fmt.Errorf("Symbol '%s' does not exist.", symbol)
 }
 
 func (r *SymbolDBRepository) UpdateSymbolStatus(ctx context.Context, symbol string, status Status) error {
@@ -249,6 +259,42 @@ func (r *SymbolDBRepository) SetIPOPrice(ctx context.Context, symbol string, ipo
 	return err
 }
 
+func (r *SymbolDBRepository) SetPriceLimitPercent(ctx context.Context, symbol string, percent float32) error {
+	query := `UPDATE symbols SET price_limit_percent = $1 WHERE symbol = $2`
+	_, err := r.db.GetPool().Exec(ctx, query, percent, symbol)
+	return err
+}
+
+func (r *SymbolDBRepository) SetVolatilityBandPercent(ctx context.Context, symbol string, percent float32) error {
+	query := `UPDATE symbols SET volatility_band_percent = $1 WHERE symbol = $2`
+	_, err := r.db.GetPool().Exec(ctx, query, percent, symbol)
+	return err
+}
+
+// SetTag 심볼 태그 설정 (예: 변동성 완화장치 발동 중 "cooldown")
+func (r *SymbolDBRepository) SetTag(ctx context.Context, symbol, tag string, value bool) error {
+	query := `UPDATE symbols SET tags = COALESCE(tags, '{}'::jsonb) || jsonb_build_object($1::text, $2::boolean) WHERE symbol = $3`
+	_, err := r.db.GetPool().Exec(ctx, query, tag, value, symbol)
+	return err
+}
+
+// GetClosePrice 마지막 거래일의 공식 종가 (종가 단일가 매매 체결가, 고정 소수점 가격)
+func (r *SymbolDBRepository) GetClosePrice(ctx context.Context, symbol string) (int64, error) {
+	var closePrice int64
+	query := `SELECT close_price FROM symbols WHERE symbol = $1`
+	err := r.db.GetPool().QueryRow(ctx, query, symbol).Scan(&closePrice)
+	if err != nil {
+		return 0, err
+	}
+	return closePrice, nil
+}
+
+func (r *SymbolDBRepository) SetClosePrice(ctx context.Context, symbol string, closePrice int64) error {
+	query := `UPDATE symbols SET close_price = $1 WHERE symbol = $2`
+	_, err := r.db.GetPool().Exec(ctx, query, closePrice, symbol)
+	return err
+}
+
 // 추가 유틸리티 메서드들
 
 func IsSymbolStructComplete(sym *Symbol) bool {
@@ -0,0 +1,242 @@
+package postgresql
+
+import (
+	"PJS_Exchange/databases"
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Trade struct {
+	ExecutionID string    `json:"execution_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Symbol      string    `json:"symbol"`
+	Price       int64     `json:"price"` // 고정 소수점 가격 (template.Price)
+	Volume      int       `json:"volume"`
+	Side        string    `json:"side"` // 체결을 일으킨 쪽 "buy" or "sell" (단일가 매매 체결은 "")
+	BuyOrderID  string    `json:"buy_order_id"`
+	SellOrderID string    `json:"sell_order_id"`
+	BuyerID     int       `json:"buyer_id"`
+	SellerID    int       `json:"seller_id"`
+	Conditions  string    `json:"conditions"`
+}
+
+// Fill 사용자 입장에서 본 체결 내역
+type Fill struct {
+	ExecutionID string    `json:"execution_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Symbol      string    `json:"symbol"`
+	OrderID     string    `json:"order_id"`
+	Side        string    `json:"side"`  // "buy" or "sell"
+	Price       int64     `json:"price"` // 고정 소수점 가격 (template.Price)
+	Quantity    int       `json:"quantity"`
+	Liquidity   string    `json:"liquidity"` // "maker", "taker" or "auction" (단일가 매매 체결)
+	Conditions  string    `json:"conditions"`
+}
+
+type Candle struct {
+	Timestamp int64 `json:"timestamp"` // 봉 시작 시각 (Unix milli)
+	Open      int64 `json:"open"`
+	High      int64 `json:"high"`
+	Low       int64 `json:"low"`
+	Close     int64 `json:"close"`
+	Volume    int64 `json:"volume"`
+}
+
+type TradeRepository interface {
+	CreateTradesTable(ctx context.Context) error
+	InsertTrades(ctx context.Context, trades []Trade) error
+	GetTradesSince(ctx context.Context, symbol string, since time.Time) ([]Trade, error)
+	GetCandles(ctx context.Context, symbol string, interval, offset time.Duration, from, to time.Time, limit int) ([]Candle, error)
+	GetFills(ctx context.Context, filter HistoryFilter) ([]Fill, error)
+}
+
+type TradeDBRepository struct {
+	db *databases.PostgresDBPool
+}
+
+func NewTradeRepository(db *databases.PostgresDBPool) *TradeDBRepository {
+	return &TradeDBRepository{db: db}
+}
+
+// CreateTradesTable trades 테이블을 생성합니다. (TimescaleDB가 설치되어 있으면 하이퍼테이블로 생성)
+func (r *TradeDBRepository) CreateTradesTable(ctx context.Context) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS trades (
+		execution_id VARCHAR(36) NOT NULL,
+		timestamp TIMESTAMPTZ NOT NULL,
+		symbol VARCHAR(20) NOT NULL,
+		price BIGINT NOT NULL,
+		volume INT NOT NULL,
+		side VARCHAR(4) NOT NULL,
+		buy_order_id VARCHAR(36) NOT NULL,
+		sell_order_id VARCHAR(36) NOT NULL,
+		buyer_id INT NOT NULL,
+		seller_id INT NOT NULL,
+		conditions VARCHAR(10) DEFAULT '',
+		PRIMARY KEY (execution_id, timestamp)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_trades_symbol_timestamp ON trades(symbol, timestamp DESC);
+	`
+	_, err := r.db.GetPool().Exec(ctx, query)
+	if err != nil {
+		log.Println("Failed to create trades table:", err)
+		return err
+	}
+
+	var timescale bool
+	err = r.db.GetPool().QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'timescaledb')`).Scan(&timescale)
+	if err != nil {
+		return err
+	}
+	if timescale {
+		_, err = r.db.GetPool().Exec(ctx, `SELECT create_hypertable('trades', 'timestamp', if_not_exists => TRUE, migrate_data => TRUE)`)
+		if err != nil {
+			log.Println("Failed to create trades hypertable:", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertTrades 체결 내역을 한번에 저장합니다. (이미 저장된 체결은 무시)
+func (r *TradeDBRepository) InsertTrades(ctx context.Context, trades []Trade) error {
+	if len(trades) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO trades (execution_id, timestamp, symbol, price, volume, side, buy_order_id, sell_order_id, buyer_id, seller_id, conditions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT DO NOTHING`
+
+	batch := &pgx.Batch{}
+	for _, trade := range trades {
+		batch.Queue(query,
+			trade.ExecutionID, trade.Timestamp, trade.Symbol, trade.Price, trade.Volume, trade.Side,
+			trade.BuyOrderID, trade.SellOrderID, trade.BuyerID, trade.SellerID, trade.Conditions)
+	}
+
+	return r.db.GetPool().SendBatch(ctx, batch).Close()
+}
+
+// GetTradesSince 특정 시각 이후의 체결 내역을 시간순으로 조회합니다.
+func (r *TradeDBRepository) GetTradesSince(ctx context.Context, symbol string, since time.Time) ([]Trade, error) {
+	query := `
+		SELECT execution_id, timestamp, symbol, price, volume, side, buy_order_id, sell_order_id, buyer_id, seller_id, conditions
+		FROM trades
+		WHERE symbol = $1 AND timestamp >= $2
+		ORDER BY timestamp ASC`
+
+	rows, err := r.db.GetPool().Query(ctx, query, symbol, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []Trade
+	for rows.Next() {
+		var trade Trade
+		if err := rows.Scan(&trade.ExecutionID, &trade.Timestamp, &trade.Symbol, &trade.Price, &trade.Volume, &trade.Side,
+			&trade.BuyOrderID, &trade.SellOrderID, &trade.BuyerID, &trade.SellerID, &trade.Conditions); err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, rows.Err()
+}
+
+// GetCandles 체결 내역을 봉 단위로 집계합니다. (from 이상 to 미만, 최근 limit개를 시간순으로 반환)
+// offset 은 봉 경계를 맞출 UTC 오프셋 (일봉을 거래소 현지 자정 기준으로 나누기 위함)
+func (r *TradeDBRepository) GetCandles(ctx context.Context, symbol string, interval, offset time.Duration, from, to time.Time, limit int) ([]Candle, error) {
+	query := `
+		SELECT bucket, open, high, low, close, volume FROM (
+			SELECT
+				(floor((extract(epoch FROM timestamp) + $2::BIGINT) / $3::BIGINT) * $3::BIGINT - $2::BIGINT)::BIGINT AS bucket,
+				(array_agg(price ORDER BY timestamp ASC))[1] AS open,
+				MAX(price) AS high,
+				MIN(price) AS low,
+				(array_agg(price ORDER BY timestamp DESC))[1] AS close,
+				SUM(volume)::BIGINT AS volume
+			FROM trades
+			WHERE symbol = $1 AND timestamp >= $4 AND timestamp < $5
+			GROUP BY bucket
+			ORDER BY bucket DESC
+			LIMIT $6
+		) c
+		ORDER BY bucket ASC`
+
+	rows, err := r.db.GetPool().Query(ctx, query, symbol, int64(offset.Seconds()), int64(interval.Seconds()), from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candles []Candle
+	for rows.Next() {
+		var candle Candle
+		if err := rows.Scan(&candle.Timestamp, &candle.Open, &candle.High, &candle.Low, &candle.Close, &candle.Volume); err != nil {
+			return nil, err
+		}
+		candle.Timestamp *= 1000
+		candles = append(candles, candle)
+	}
+	return candles, rows.Err()
+}
+
+// GetFills 사용자의 체결 내역을 시간순으로 조회합니다. (Status 조건은 사용하지 않음)
+func (r *TradeDBRepository) GetFills(ctx context.Context, filter HistoryFilter) ([]Fill, error) {
+	query := `
+		SELECT execution_id, timestamp, symbol, order_id, side, price, volume,
+			CASE WHEN aggressor = '' THEN 'auction' WHEN side = aggressor THEN 'taker' ELSE 'maker' END AS liquidity, conditions
+		FROM (
+			SELECT execution_id, timestamp, symbol, buy_order_id AS order_id, 'buy' AS side, price, volume, side AS aggressor, conditions
+			FROM trades WHERE buyer_id = $1
+			UNION ALL
+			SELECT execution_id, timestamp, symbol, sell_order_id AS order_id, 'sell' AS side, price, volume, side AS aggressor, conditions
+			FROM trades WHERE seller_id = $1
+		) f
+		WHERE TRUE`
+	args := []interface{}{filter.UserID}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		query += " AND " + condition + " $" + strconv.Itoa(len(args))
+	}
+	if filter.Symbol != "" {
+		addCondition("symbol =", filter.Symbol)
+	}
+	if filter.Side != "" {
+		addCondition("side =", filter.Side)
+	}
+	if filter.From != nil {
+		addCondition("timestamp >=", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("timestamp <", *filter.To)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query += " ORDER BY timestamp ASC, execution_id ASC, side ASC LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	rows, err := r.db.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fills := make([]Fill, 0)
+	for rows.Next() {
+		var fill Fill
+		if err := rows.Scan(&fill.ExecutionID, &fill.Timestamp, &fill.Symbol, &fill.OrderID, &fill.Side, &fill.Price,
+			&fill.Quantity, &fill.Liquidity, &fill.Conditions); err != nil {
+			return nil, err
+		}
+		fills = append(fills, fill)
+	}
+	return fills, rows.Err()
+}
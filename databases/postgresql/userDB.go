@@ -22,14 +22,15 @@ const (
 )
 
 type User struct {
-	ID        int       `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"Email"`
-	Password  string    `json:"Password"` // Must be hashed
-	CreatedAt time.Time `json:"CreatedAt"`
-	Admin     bool      `json:"admin"`
-	Type      int       `json:"type"`
-	Enabled   bool      `json:"enabled"`
+	ID                  int       `json:"id"`
+	Username            string    `json:"username"`
+	Email               string    `json:"Email"`
+	Password            string    `json:"Password"` // Must be hashed
+	CreatedAt           time.Time `json:"CreatedAt"`
+	Admin               bool      `json:"admin"`
+	Type                int       `json:"type"`
+	Enabled             bool      `json:"enabled"`
+	SelfTradePrevention string    `json:"self_trade_prevention"` // 주문에 지정하지 않은 경우 사용할 자기매매 방지 모드 (빈 값이면 사용 안함)
 	//APIKey    uuid.UUID `json:"api_key"`
 }
 
@@ -62,9 +63,13 @@ func (r *UserDBRepository) CreateUsersTable(ctx context.Context) error {
 	    created_at TIMESTAMPTZ NOT NULL,
     	admin BOOLEAN NOT NULL DEFAULT FALSE,
     	type INT NOT NULL DEFAULT 0,
-	    enabled BOOLEAN NOT NULL DEFAULT FALSE
+	    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+	    self_trade_prevention VARCHAR(20) NOT NULL DEFAULT ''
 -- 	    api_key UUID
-	);`
+	);
+
+	-- 기존 테이블에 추가된 컬럼
+	ALTER TABLE users ADD COLUMN IF NOT EXISTS self_trade_prevention VARCHAR(20) NOT NULL DEFAULT '';`
 
 	_, err := r.db.GetPool().Exec(ctx, query)
 	if err != nil {
@@ -149,8 +154,8 @@ func (r *UserDBRepository) CreateUser(ctx context.Context, username string, emai
 
 func (r *UserDBRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	user := &User{}
-	err := r.db.GetPool().QueryRow(ctx, "SELECT id, username, email, password, created_at, enabled, admin, type FROM users WHERE email=$1", email).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.Enabled, &user.Admin, &user.Type)
+	err := r.db.GetPool().QueryRow(ctx, "SELECT id, username, email, password, created_at, enabled, admin, type, self_trade_prevention FROM users WHERE email=$1", email).
+		Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.Enabled, &user.Admin, &user.Type, &user.SelfTradePrevention)
 	if err != nil {
 		log.Println("Failed to get user by email:", err)
 		return nil, err
@@ -160,8 +165,8 @@ func (r *UserDBRepository) GetUserByEmail(ctx context.Context, email string) (*U
 
 func (r *UserDBRepository) GetUserByID(ctx context.Context, userID int) (*User, error) {
 	user := &User{}
-	err := r.db.GetPool().QueryRow(ctx, "SELECT id, username, email, password, created_at, enabled, admin, type FROM users WHERE id=$1", userID).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.Enabled, &user.Admin, &user.Type)
+	err := r.db.GetPool().QueryRow(ctx, "SELECT id, username, email, password, created_at, enabled, admin, type, self_trade_prevention FROM users WHERE id=$1", userID).
+		Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.Enabled, &user.Admin, &user.Type, &user.SelfTradePrevention)
 	if err != nil {
 		log.Println("Failed to get user by ID:", err)
 		return nil, err
@@ -257,6 +262,16 @@ func (r *UserDBRepository) SetUserType(ctx context.Context, userID int, userType
 	return nil
 }
 
+// SetSelfTradePrevention 사용자의 기본 자기매매 방지 모드 변경
+func (r *UserDBRepository) SetSelfTradePrevention(ctx context.Context, userID int, mode string) error {
+	_, err := r.db.GetPool().Exec(ctx, "UPDATE users SET self_trade_prevention=$1 WHERE id=$2", mode, userID)
+	if err != nil {
+		log.Println("Failed to set self-trade prevention:", err)
+		return err
+	}
+	return nil
+}
+
 func (r *UserDBRepository) IsUserEnabled(ctx context.Context, userID int) (bool, error) {
 	var enabled bool
 	err := r.db.GetPool().QueryRow(ctx, "SELECT enabled FROM users WHERE id=$1", userID).Scan(&enabled)
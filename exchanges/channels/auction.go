@@ -0,0 +1,374 @@
+package channels
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/routes/ws"
+	t "PJS_Exchange/template"
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/btree"
+	"github.com/google/uuid"
+)
+
+// auctionPhase 세션별 단일가 매매 단계 (프리장: 시가 단일가, 포스트장: 종가 단일가, 정규장: 접속 매매)
+func auctionPhase(session string) (string, bool) {
+	switch session {
+	case "pre":
+		return t.AuctionOpening, true
+	case "post":
+		return t.AuctionClosing, true
+	case "regular", "closed":
+		return "", true
+	}
+	// 세션을 알 수 없으면 현재 단계 유지
+	return "", false
+}
+
+// SyncSession 세션 변경을 거래 중인 모든 심볼의 매칭 엔진에 반영 (같은 단계면 무시)
+func (po *ProcessOrders) SyncSession(session string) {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	for _, sh := range po.shards {
+		if !sh.Running() {
+			// 거래 정지된 심볼은 재개할 때 반영
+			continue
+		}
+		sh := sh
+		sh.runTask(func() {
+			sh.syncSession(session)
+		})
+	}
+}
+
+func (sh *SymbolShard) syncSession(session string) {
+	phase, ok := auctionPhase(session)
+	if exchanges.IsHalted() {
+		// 거래 중단 중에는 세션과 상관없이 매칭 중단
+		phase, ok = t.AuctionHalt, true
+	}
+	if !ok {
+		return
+	}
+	// 변동성 완화장치는 정해진 시간 동안 유지 (세션이 바뀌면 바로 종료)
+	if sh.auction == t.AuctionVolatility && phase == "" && sh.volatilityUntil > time.Now().UnixMilli() {
+		return
+	}
+	sh.setAuction(phase)
+}
+
+// setAuction 단일가 매매 단계 변경 (단계가 끝나면 모아둔 주문을 한 가격으로 체결)
+// 저널에 기록되므로 복구 시에도 주문과 같은 순서로 체결됨
+func (sh *SymbolShard) setAuction(phase string) {
+	if phase == sh.auction {
+		return
+	}
+
+	if sh.journal != nil && !isReplaying(sh.Symbol) {
+		seq, err := sh.journal.AppendPhase(phase)
+		if err != nil {
+			log.Printf("Error writing order journal for %s: %v", sh.Symbol, err)
+		} else {
+			defer func() {
+				if err := sh.journal.AppendDone(seq); err != nil {
+					log.Printf("Error writing order journal for %s: %v", sh.Symbol, err)
+				}
+			}()
+		}
+	}
+
+	previous := sh.auction
+	sh.auction = phase
+	if previous == t.AuctionVolatility || phase == t.AuctionVolatility {
+		sh.setCooldown(phase == t.AuctionVolatility)
+	}
+	// 거래 중단이 시작되면 겹친 호가는 그대로 두고 재개할 때 체결
+	if previous != "" && phase != t.AuctionHalt {
+		sh.uncrossAuction(previous)
+	}
+	if phase != "" {
+		sh.broadcastIndicative()
+		return
+	}
+
+	// 단일가 체결가에 도달한 스탑 주문 발동
+	sh.triggerStops()
+}
+
+// auctionLeaves 가격대의 남은 수량 합계 (아이스버그 주문은 숨겨진 수량 포함)
+func (sh *SymbolShard) auctionLeaves(side string, price t.Price) int {
+	total := 0
+	if seq := sh.DepthExecutionSeq[side][price]; seq != nil {
+		for _, orderID := range seq.Values() {
+			if sh.DepthOrderIDIndex[orderID] != nil {
+				total += leavesQuantity(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+			}
+		}
+	}
+	return total
+}
+
+// auctionPrice 단일가 체결 가격 계산 (같은 사용자의 주문끼리는 체결 수량에서 제외, 체결 수량 최대 -> 잔량 최소 -> 최종 체결가와 가까운 가격 -> 낮은 가격 순)
+// imbalance 는 체결 가격에서 체결되지 못하는 수량 (매수 잔량 +, 매도 잔량 -)
+func (sh *SymbolShard) auctionPrice() (price t.Price, volume int, imbalance int) {
+	highBid := sh.Depth.BidTree.Max()
+	lowAsk := sh.Depth.AskTree.Min()
+	if highBid == nil || lowAsk == nil || highBid.(t.PriceItem) < lowAsk.(t.PriceItem) {
+		// 매수 호가와 매도 호가가 겹치지 않으면 체결 불가
+		return 0, 0, 0
+	}
+
+	// 후보 가격은 겹치는 구간 안의 모든 호가
+	bids := make(map[t.Price]int)
+	asks := make(map[t.Price]int)
+	demand := 0
+	sh.Depth.BidTree.AscendGreaterOrEqual(lowAsk, func(i btree.Item) bool {
+		p := t.Price(i.(t.PriceItem))
+		bids[p] = sh.auctionLeaves(t.Bids, p)
+		demand += bids[p]
+		return true
+	})
+	sh.Depth.AskTree.AscendRange(lowAsk, t.PriceItem(highBid.(t.PriceItem)+1), func(i btree.Item) bool {
+		p := t.Price(i.(t.PriceItem))
+		asks[p] = sh.auctionLeaves(t.Asks, p)
+		return true
+	})
+	candidates := make([]t.Price, 0, len(bids)+len(asks))
+	for p := range bids {
+		candidates = append(candidates, p)
+	}
+	for p := range asks {
+		if _, ok := bids[p]; !ok {
+			candidates = append(candidates, p)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i] < candidates[j]
+	})
+
+	abs := func(x int64) int64 {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+	reference := sh.Depth.LastPrice
+	// 같은 사용자의 주문끼리는 체결하지 않으므로 양쪽에 같은 사용자가 있으면 가격마다 짝지어 체결 수량 계산
+	selfTrade := sh.auctionSelfTrade(t.Price(lowAsk.(t.PriceItem)), t.Price(highBid.(t.PriceItem)))
+
+	// 낮은 가격부터 누적 매도 수량(가격 이하)은 늘리고 누적 매수 수량(가격 이상)은 줄여가며 비교
+	supply := 0
+	for _, p := range candidates {
+		supply += asks[p]
+		v := min(demand, supply)
+		if selfTrade && v > 0 {
+			v = sh.auctionPairs(p, v, nil)
+		}
+		imb := demand - supply
+		better := v > volume ||
+			(v == volume && abs(int64(imb)) < abs(int64(imbalance))) ||
+			(v == volume && abs(int64(imb)) == abs(int64(imbalance)) && reference > 0 && abs(int64(p-reference)) < abs(int64(price-reference)))
+		if v > 0 && better {
+			price, volume, imbalance = p, v, imb
+		}
+		demand -= bids[p]
+	}
+	return price, volume, imbalance
+}
+
+// uncrossAuction 단일가 매매 종료 시 모아둔 주문을 한 가격으로 체결 (종가 단일가는 공식 종가 저장)
+func (sh *SymbolShard) uncrossAuction(phase string) {
+	price, volume, _ := sh.auctionPrice()
+	if volume > 0 {
+		sh.matchAuction(phase, price, volume)
+		log.Printf("%s auction for %s uncrossed at %d (volume %d)", phase, sh.Symbol, price, volume)
+	}
+	if phase == t.AuctionClosing {
+		sh.saveClosePrice()
+	}
+}
+
+// auctionOrderIDs 체결 가격 이상의 매수 주문과 이하의 매도 주문 (가격 우선, 시간 우선 순서)
+func (sh *SymbolShard) auctionOrderIDs(price t.Price) (bidIDs, askIDs []string) {
+	collect := func(side string, orderIDs *[]string) func(i btree.Item) bool {
+		return func(i btree.Item) bool {
+			if seq := sh.DepthExecutionSeq[side][t.Price(i.(t.PriceItem))]; seq != nil {
+				*orderIDs = append(*orderIDs, seq.Values()...)
+			}
+			return true
+		}
+	}
+	sh.Depth.BidTree.DescendRange(t.PriceItem(math.MaxInt64), t.PriceItem(price-1), collect(t.Bids, &bidIDs))
+	sh.Depth.AskTree.AscendRange(t.PriceItem(0), t.PriceItem(price+1), collect(t.Asks, &askIDs))
+	return bidIDs, askIDs
+}
+
+// auctionPairs 체결 가격에서 매수, 매도 주문을 가격 우선, 시간 우선 순서로 짝지어 체결 수량 계산
+// 같은 사용자의 주문끼리는 짝짓지 않고 다음 매도 주문과 짝지음 (자기매매 방지), match 가 nil 이 아니면 짝마다 호출
+func (sh *SymbolShard) auctionPairs(price t.Price, volume int, match func(bidID, askID string, quantity int)) int {
+	bidIDs, askIDs := sh.auctionOrderIDs(price)
+	leaves := make(map[string]int, len(bidIDs)+len(askIDs))
+	for _, orderID := range append(bidIDs, askIDs...) {
+		if sh.DepthOrderIDIndex[orderID] != nil {
+			leaves[orderID] = leavesQuantity(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+		}
+	}
+
+	matched := 0
+	first := 0 // 아직 남은 수량이 있는 첫 매도 주문
+	for _, bidID := range bidIDs {
+		for ; first < len(askIDs) && leaves[askIDs[first]] <= 0; first++ {
+		}
+		if volume <= 0 || first == len(askIDs) {
+			break
+		}
+		if leaves[bidID] <= 0 {
+			continue
+		}
+		buyerID := sh.DepthOrderIDIndex[bidID][0].(int)
+		for j := first; j < len(askIDs) && leaves[bidID] > 0 && volume > 0; j++ {
+			askID := askIDs[j]
+			if leaves[askID] <= 0 || sh.DepthOrderIDIndex[askID][0].(int) == buyerID {
+				continue
+			}
+			quantity := min(leaves[bidID], leaves[askID], volume)
+			leaves[bidID] -= quantity
+			leaves[askID] -= quantity
+			volume -= quantity
+			matched += quantity
+			if match != nil {
+				match(bidID, askID, quantity)
+			}
+		}
+	}
+	return matched
+}
+
+// auctionSelfTrade 겹치는 구간 안에 같은 사용자의 매수, 매도 주문이 모두 있는지 확인
+func (sh *SymbolShard) auctionSelfTrade(lowAsk, highBid t.Price) bool {
+	bidIDs, _ := sh.auctionOrderIDs(lowAsk)
+	_, askIDs := sh.auctionOrderIDs(highBid)
+	buyers := make(map[int]bool)
+	for _, orderID := range bidIDs {
+		if index := sh.DepthOrderIDIndex[orderID]; index != nil {
+			buyers[index[0].(int)] = true
+		}
+	}
+	for _, orderID := range askIDs {
+		if index := sh.DepthOrderIDIndex[orderID]; index != nil && buyers[index[0].(int)] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAuction 체결 가격 이상의 매수 주문과 이하의 매도 주문을 가격 우선, 시간 우선 순서로 체결 (같은 사용자의 주문끼리는 체결하지 않음)
+func (sh *SymbolShard) matchAuction(phase string, price t.Price, volume int) {
+	depth := &sh.Depth
+
+	conditions := "oa" // oa: 시가 단일가, ca: 종가 단일가, va: 변동성 완화장치 단일가, ha: 거래 재개 단일가
+	switch phase {
+	case t.AuctionClosing:
+		conditions = "ca"
+	case t.AuctionHalt:
+		conditions = "ha"
+	case t.AuctionVolatility:
+		conditions = "va"
+	}
+	timestamp := time.Now().UnixMilli()
+	touched := map[string]map[t.Price]bool{t.Bids: {}, t.Asks: {}}
+
+	sh.auctionPairs(price, volume, func(bidID, askID string, quantity int) {
+		bidIndex, askIndex := sh.DepthOrderIDIndex[bidID], sh.DepthOrderIDIndex[askID]
+		executionID := uuid.NewString() // 체결 알림과 원장에 같은 체결 ID 사용
+		touched[t.Bids][bidIndex[2].(t.Price)] = true
+		touched[t.Asks][askIndex[2].(t.Price)] = true
+
+		// 아이스버그 주문은 숨겨진 수량까지 체결
+		reportFill(depth, sh.Symbol, bidID, price, quantity, executionID)
+		reportFill(depth, sh.Symbol, askID, price, quantity, executionID)
+		sh.fillAuctionOrder(bidID, t.SideBuy, quantity)
+		sh.fillAuctionOrder(askID, t.SideSell, quantity)
+
+		broadcastTrade(depth, t.Ledger{
+			Timestamp:   timestamp,
+			Symbol:      sh.Symbol,
+			Price:       price,
+			Volume:      quantity,
+			BuyOrderID:  bidID,
+			SellOrderID: askID,
+			BuyerID:     bidIndex[0].(int),
+			SellerID:    askIndex[0].(int),
+			ExecutionID: executionID,
+			Conditions:  conditions,
+		})
+	})
+
+	// 체결된 가격대 호가 브로드캐스트
+	for p := range touched[t.Bids] {
+		broadcastDepth(t.UpdateDepth{Timestamp: timestamp, Symbol: sh.Symbol, Side: t.Bids, Price: p, Quantity: depth.TotalBids[p]})
+	}
+	for p := range touched[t.Asks] {
+		broadcastDepth(t.UpdateDepth{Timestamp: timestamp, Symbol: sh.Symbol, Side: t.Asks, Price: p, Quantity: depth.TotalAsks[p]})
+	}
+}
+
+// fillAuctionOrder 단일가 체결된 수량만큼 호가에서 차감 (노출 수량을 넘어 체결된 아이스버그 주문은 숨겨진 수량으로 다시 채움)
+func (sh *SymbolShard) fillAuctionOrder(orderID, side string, quantity int) {
+	index := sh.DepthOrderIDIndex[orderID]
+	if index == nil {
+		return
+	}
+	if _, ok := sh.Depth.Executions[orderID]; !ok {
+		// 전부 체결된 주문은 호가에서 제거
+		processCancel(&t.OrderRequest{OrderID: orderID, Side: side}, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+		return
+	}
+
+	price := index[2].(t.Price)
+	previous, _ := lookupOrder(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+	processModify(&t.OrderRequest{
+		Timestamp: time.Now().UnixMilli(),
+		UserID:    index[0].(int),
+		Symbol:    sh.Symbol,
+		OrderID:   orderID,
+		Side:      side,
+		Price:     price,
+		Quantity:  max(index[3].(int)-quantity, 0),
+		Status:    t.StatusModified,
+	}, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+	replenishIceberg(sh.Symbol, orderID, side, price, previous.CreatedAt, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+}
+
+// saveClosePrice 공식 종가 저장 (종가 단일가 체결이 없으면 최종 체결가, 복구 중에는 이미 저장되었으므로 생략)
+func (sh *SymbolShard) saveClosePrice() {
+	if !shouldPersist(sh.Symbol) || sh.Depth.LastPrice <= 0 {
+		return
+	}
+	if err := postgresApp.Get().SymbolRepo().SetClosePrice(context.Background(), sh.Symbol, int64(sh.Depth.LastPrice)); err != nil {
+		log.Printf("Error saving close price for %s: %v", sh.Symbol, err)
+	}
+}
+
+// broadcastIndicative 단일가 매매 중 예상 체결가, 체결 수량을 호가 WebSocket 으로 전송
+func (sh *SymbolShard) broadcastIndicative() {
+	if sh.auction == "" || isReplaying(sh.Symbol) {
+		return
+	}
+	price, volume, imbalance := sh.auctionPrice()
+	indicative := t.AuctionIndicative{
+		Timestamp: time.Now().UnixMilli(),
+		Symbol:    sh.Symbol,
+		Side:      t.Auction,
+		Phase:     sh.auction,
+		Price:     price,
+		Quantity:  volume,
+		Imbalance: imbalance,
+	}
+	ws.DepthHub.BroadcastMessage(indicative.Timestamp, ws.AuctionPayload(indicative))
+}
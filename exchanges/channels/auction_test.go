@@ -0,0 +1,180 @@
+package channels
+
+import (
+	"PJS_Exchange/template"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// testOrder 테스트용 지정가 주문
+type testOrder struct {
+	orderID  string
+	userID   int
+	side     string
+	price    template.Price
+	quantity int
+}
+
+// newTestShard 주문을 호가에 바로 올린 매칭 엔진 (체결 처리 없이 시간 우선 순서대로 추가)
+func newTestShard(lastPrice template.Price, orders []testOrder) *SymbolShard {
+	sh := NewSymbolShard("TEST")
+	sh.Depth.LastPrice = lastPrice
+	for i, o := range orders {
+		processOpen(&template.OrderRequest{
+			Timestamp: int64(i + 1),
+			UserID:    o.userID,
+			OrderID:   o.orderID,
+			Symbol:    sh.Symbol,
+			Side:      o.side,
+			OrderType: template.OrderTypeLimit,
+			Price:     o.price,
+			Quantity:  o.quantity,
+			Status:    template.StatusOpen,
+		}, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+	}
+	return sh
+}
+
+func TestAuctionPrice(t *testing.T) {
+	// 체결 수량이 같은 가격이 두 개 (100: 매수 잔량 4, 101: 매도 잔량 4)
+	tied := []testOrder{
+		{"b1", 1, template.SideBuy, 101, 10},
+		{"b2", 2, template.SideBuy, 100, 4},
+		{"a1", 3, template.SideSell, 100, 10},
+		{"a2", 4, template.SideSell, 101, 4},
+	}
+
+	tests := []struct {
+		name      string
+		lastPrice template.Price
+		orders    []testOrder
+		price     template.Price
+		volume    int
+		imbalance int
+	}{
+		{
+			name: "no cross",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 99, 10},
+				{"a1", 2, template.SideSell, 100, 10},
+			},
+		},
+		{
+			name: "maximum volume",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 105, 10},
+				{"b2", 2, template.SideBuy, 100, 10},
+				{"a1", 3, template.SideSell, 95, 10},
+				{"a2", 4, template.SideSell, 100, 10},
+			},
+			price: 100, volume: 20, imbalance: 0,
+		},
+		{
+			name: "minimum imbalance",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 101, 10},
+				{"b2", 2, template.SideBuy, 100, 4},
+				{"a1", 3, template.SideSell, 100, 10},
+				{"a2", 4, template.SideSell, 101, 3},
+			},
+			price: 101, volume: 10, imbalance: -3,
+		},
+		{name: "closest to last price (upper)", lastPrice: 101, orders: tied, price: 101, volume: 10, imbalance: -4},
+		{name: "closest to last price (lower)", lastPrice: 100, orders: tied, price: 100, volume: 10, imbalance: 4},
+		{name: "lowest price without last price", orders: tied, price: 100, volume: 10, imbalance: 4},
+		{
+			name: "same user pair excluded",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 100, 10},
+				{"a1", 1, template.SideSell, 100, 10},
+				{"a2", 2, template.SideSell, 100, 5},
+			},
+			price: 100, volume: 5, imbalance: -5,
+		},
+		{
+			name: "only same user",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 100, 10},
+				{"a1", 1, template.SideSell, 100, 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := newTestShard(tt.lastPrice, tt.orders)
+			price, volume, imbalance := sh.auctionPrice()
+			if price != tt.price || volume != tt.volume || imbalance != tt.imbalance {
+				t.Errorf("auctionPrice() = (%d, %d, %d), want (%d, %d, %d)", price, volume, imbalance, tt.price, tt.volume, tt.imbalance)
+			}
+		})
+	}
+}
+
+func TestAuctionPairs(t *testing.T) {
+	type pair struct {
+		bidID, askID string
+		quantity     int
+	}
+
+	tests := []struct {
+		name   string
+		orders []testOrder
+		price  template.Price
+		volume int
+		pairs  []pair
+	}{
+		{
+			name: "price then time priority",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 100, 5},
+				{"b2", 2, template.SideBuy, 101, 5},
+				{"a1", 3, template.SideSell, 100, 4},
+				{"a2", 4, template.SideSell, 99, 4},
+			},
+			price: 100, volume: math.MaxInt,
+			pairs: []pair{{"b2", "a2", 4}, {"b2", "a1", 1}, {"b1", "a1", 3}},
+		},
+		{
+			name: "same user skipped",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 101, 10},
+				{"b2", 2, template.SideBuy, 100, 5},
+				{"a1", 1, template.SideSell, 99, 5},
+				{"a2", 3, template.SideSell, 100, 10},
+			},
+			price: 100, volume: math.MaxInt,
+			pairs: []pair{{"b1", "a2", 10}, {"b2", "a1", 5}},
+		},
+		{
+			name: "volume limit",
+			orders: []testOrder{
+				{"b1", 1, template.SideBuy, 100, 10},
+				{"a1", 2, template.SideSell, 100, 10},
+			},
+			price: 100, volume: 6,
+			pairs: []pair{{"b1", "a1", 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := newTestShard(0, tt.orders)
+			var pairs []pair
+			matched := sh.auctionPairs(tt.price, tt.volume, func(bidID, askID string, quantity int) {
+				pairs = append(pairs, pair{bidID, askID, quantity})
+			})
+			if !reflect.DeepEqual(pairs, tt.pairs) {
+				t.Errorf("auctionPairs() pairs = %v, want %v", pairs, tt.pairs)
+			}
+			total := 0
+			for _, p := range tt.pairs {
+				total += p.quantity
+			}
+			if matched != total {
+				t.Errorf("auctionPairs() = %d, want %d", matched, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package channels
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	batchWriteSize     = 500             // 한번에 저장할 최대 개수
+	batchWriteInterval = 1 * time.Second // 저장 주기
+	batchBufferLimit   = 200000          // 저장하지 못하고 쌓아둘 최대 개수 (DB 장애 시 메모리 보호)
+	batchMaxAttempts   = 5               // DB가 거부한 묶음의 최대 저장 시도 횟수
+)
+
+var (
+	TW *BatchWriter[postgresql.Trade]      // 체결 내역 저장
+	EW *BatchWriter[postgresql.OrderEvent] // 주문 이벤트 저장
+)
+
+// BatchWriter 데이터를 모아서 DB에 저장 (매칭 엔진이 DB를 기다리지 않도록 메모리 버퍼에만 쌓음)
+type BatchWriter[T any] struct {
+	name  string
+	write func(ctx context.Context, items []T) error
+
+	buffer   []T
+	lock     sync.Mutex
+	dropped  int // 버퍼가 가득 차서 버린 개수 (다음 저장 때 기록)
+	attempts int // 맨 앞 묶음의 저장 실패 횟수
+
+	flush chan struct{} // 버퍼가 가득 찼을 때 즉시 저장 신호
+	quit  chan struct{}
+	done  chan struct{}
+}
+
+func NewBatchWriter[T any](name string, write func(ctx context.Context, items []T) error) *BatchWriter[T] {
+	return &BatchWriter[T]{
+		name:  name,
+		write: write,
+		flush: make(chan struct{}, 1),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Add 버퍼에 추가 (블로킹 없음, 버퍼가 가득 차면 버림)
+func (bw *BatchWriter[T]) Add(item T) {
+	bw.lock.Lock()
+	if len(bw.buffer) >= batchBufferLimit {
+		bw.dropped++
+		bw.lock.Unlock()
+		return
+	}
+	bw.buffer = append(bw.buffer, item)
+	full := len(bw.buffer) >= batchWriteSize
+	bw.lock.Unlock()
+
+	if full {
+		select {
+		case bw.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (bw *BatchWriter[T]) Create() {
+	defer close(bw.done)
+
+	ticker := time.NewTicker(batchWriteInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			bw.writeAll()
+		case <-bw.flush:
+			bw.writeAll()
+		case <-bw.quit:
+			bw.writeAll()
+			return
+		}
+	}
+}
+
+// Destroy 남은 데이터를 모두 저장한 후 종료
+func (bw *BatchWriter[T]) Destroy() {
+	close(bw.quit)
+	<-bw.done
+}
+
+func (bw *BatchWriter[T]) writeAll() {
+	bw.lock.Lock()
+	if bw.dropped > 0 {
+		log.Printf("Dropped %d %s: buffer limit %d reached", bw.dropped, bw.name, batchBufferLimit)
+		bw.dropped = 0
+	}
+	bw.lock.Unlock()
+
+	for {
+		bw.lock.Lock()
+		if len(bw.buffer) == 0 {
+			bw.lock.Unlock()
+			return
+		}
+		n := min(len(bw.buffer), batchWriteSize)
+		batch := bw.buffer[:n:n]
+		bw.lock.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := bw.write(ctx, batch)
+		cancel()
+		if err != nil {
+			// DB가 거부한 묶음(제약 조건, 인코딩 오류 등)은 몇 번 재시도 후 건너뜀 (뒤의 데이터가 계속 막히지 않도록)
+			var pgErr *pgconn.PgError
+			bw.attempts++
+			if !errors.As(err, &pgErr) || bw.attempts < batchMaxAttempts {
+				// 연결 오류 등은 버퍼에 남겨두고 다음 주기에 재시도
+				log.Printf("Error saving %d %s: %v", len(batch), bw.name, err)
+				return
+			}
+			log.Printf("Skipping %d %s after %d attempts: %v", len(batch), bw.name, bw.attempts, err)
+		}
+		bw.attempts = 0
+
+		bw.lock.Lock()
+		bw.buffer = bw.buffer[n:]
+		bw.lock.Unlock()
+	}
+}
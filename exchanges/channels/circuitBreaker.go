@@ -0,0 +1,285 @@
+package channels
+
+import (
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/routes/ws"
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	haltLock     sync.Mutex
+	autoHaltDate string // 서킷 브레이커가 자동 발동한 날짜 (하루 한 번만 발동)
+)
+
+// circuitBreakerPercent 서킷 브레이커 자동 발동 기준 (기준가 대비 하락률 %, 0이면 자동 발동 안함)
+func circuitBreakerPercent() float64 {
+	percent, err := strconv.ParseFloat(utils.GetEnv("CIRCUIT_BREAKER_PERCENT", "8"), 64)
+	if err != nil || percent < 0 {
+		percent = 8
+	}
+	return percent
+}
+
+// circuitBreakerDuration 서킷 브레이커 자동 발동 시 거래 중단 시간
+func circuitBreakerDuration() time.Duration {
+	minutes, err := strconv.Atoi(utils.GetEnv("CIRCUIT_BREAKER_MINUTES", "20"))
+	if err != nil || minutes <= 0 {
+		minutes = 20
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// haltPath 거래 중단 상태 파일 (재시작 후에도 거래 중단 유지)
+func haltPath() string {
+	return filepath.Join(journalDir(), "halt.json")
+}
+
+// saveHalt 거래 중단 상태 저장 (중단 중이 아니면 파일 삭제)
+func saveHalt(halt exchanges.TradingHalt) error {
+	if !halt.Halted {
+		if err := os.Remove(haltPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(journalDir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(halt)
+	if err != nil {
+		return err
+	}
+
+	// 임시 파일에 쓴 뒤 교체 (저장 도중 종료되어도 이전 상태 유지)
+	tmpPath := haltPath() + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, haltPath())
+}
+
+// restoreHalt 저장된 거래 중단 상태 복구 후 재개 예약 (심볼의 매칭 엔진을 시작하기 전에 호출)
+// 재개 시각이 이미 지났으면 복구하지 않음
+func (po *ProcessOrders) restoreHalt() {
+	data, err := os.ReadFile(haltPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Printf("Error reading trading halt state: %v", err)
+		return
+	}
+	var halt exchanges.TradingHalt
+	if err := json.Unmarshal(data, &halt); err != nil {
+		log.Printf("Error parsing trading halt state: %v", err)
+		return
+	}
+
+	haltLock.Lock()
+	defer haltLock.Unlock()
+
+	if halt.Source == "auto" {
+		autoHaltDate = time.UnixMilli(halt.HaltedAt).Format("2006-01-02")
+	}
+	if !halt.Halted || (halt.ResumeAt > 0 && halt.ResumeAt <= time.Now().UnixMilli()) {
+		if err := saveHalt(exchanges.TradingHalt{}); err != nil {
+			log.Printf("Error saving trading halt state: %v", err)
+		}
+		return
+	}
+	if halt.ResumeAt > 0 {
+		resumeAt := halt.ResumeAt
+		time.AfterFunc(time.Until(time.UnixMilli(resumeAt)), func() {
+			po.resumeIfDue(resumeAt)
+		})
+	}
+	exchanges.SetHalt(halt)
+	log.Printf("Restored market halt by %s: %s (resume at %d)", halt.Source, halt.Reason, halt.ResumeAt)
+}
+
+// HaltMarket 거래소 전체 거래 중단 (duration 이 0이면 관리자가 재개할 때까지 유지)
+// 이미 중단 중이면 사유와 재개 시각만 갱신
+func (po *ProcessOrders) HaltMarket(reason, source string, duration time.Duration) exchanges.TradingHalt {
+	haltLock.Lock()
+	defer haltLock.Unlock()
+
+	now := time.Now()
+	halt := exchanges.TradingHalt{
+		Reason:   reason,
+		Source:   source,
+		HaltedAt: now.UnixMilli(),
+	}
+	if previous := exchanges.GetHalt(); previous.Halted {
+		halt.HaltedAt = previous.HaltedAt
+	}
+	if duration > 0 {
+		halt.ResumeAt = now.Add(duration).UnixMilli()
+		resumeAt := halt.ResumeAt
+		time.AfterFunc(duration, func() {
+			po.resumeIfDue(resumeAt)
+		})
+	}
+	exchanges.SetHalt(halt)
+	halt = exchanges.GetHalt()
+	if err := saveHalt(halt); err != nil {
+		log.Printf("Error saving trading halt state: %v", err)
+	}
+
+	// 모든 심볼의 매칭 중단 (거래 중단 단계로 전환)
+	po.SyncSession(exchanges.MarketStatus)
+	log.Printf("Market halted by %s: %s (resume at %d)", source, reason, halt.ResumeAt)
+
+	broadcastSessionStatus(t.SessionStatus{
+		Session:  "halt",
+		Reason:   halt.Reason,
+		ResumeAt: halt.ResumeAt,
+	})
+	return halt
+}
+
+// ResumeMarket 거래 재개 (거래 중단 중이 아니면 false)
+func (po *ProcessOrders) ResumeMarket() bool {
+	haltLock.Lock()
+	defer haltLock.Unlock()
+	return po.resume()
+}
+
+// resumeIfDue 예약된 재개 시각에 거래 재개 (그 사이 재개되었거나 다시 중단된 경우 무시)
+func (po *ProcessOrders) resumeIfDue(resumeAt int64) {
+	haltLock.Lock()
+	defer haltLock.Unlock()
+
+	if halt := exchanges.GetHalt(); halt.Halted && halt.ResumeAt == resumeAt {
+		po.resume()
+	}
+}
+
+func (po *ProcessOrders) resume() bool {
+	if !exchanges.IsHalted() {
+		return false
+	}
+	exchanges.ClearHalt()
+	if err := saveHalt(exchanges.TradingHalt{}); err != nil {
+		log.Printf("Error saving trading halt state: %v", err)
+	}
+
+	// 현재 세션으로 복귀 (겹친 호가는 단일가로 체결 후 접속 매매 재개)
+	po.SyncSession(exchanges.MarketStatus)
+	log.Println("Market resumed")
+
+	broadcastSessionStatus(t.SessionStatus{
+		Session: "resume",
+	})
+	return true
+}
+
+// CheckCircuitBreaker 정규장 중 시장이 기준가 대비 일정 비율 이상 하락하면 거래 중단 (하루 한 번)
+func (po *ProcessOrders) CheckCircuitBreaker() {
+	percent := circuitBreakerPercent()
+	if percent <= 0 || exchanges.MarketStatus != "regular" || exchanges.IsHalted() {
+		return
+	}
+	today := time.Now().Format("2006-01-02")
+	haltLock.Lock()
+	triggered := autoHaltDate == today
+	haltLock.Unlock()
+	if triggered {
+		return
+	}
+
+	change, ok := po.MarketChange()
+	if !ok || change > -percent {
+		return
+	}
+
+	haltLock.Lock()
+	autoHaltDate = today
+	haltLock.Unlock()
+	po.HaltMarket(fmt.Sprintf("Market dropped %.2f%% from the previous close", -change), "auto", circuitBreakerDuration())
+}
+
+// MarketChange 기준가 대비 시장 등락률 (%)
+// 지수 심볼이 있으면 지수 중 가장 크게 하락한 값, 없으면 거래 중인 전체 심볼의 평균 (체결이 없는 심볼은 제외)
+func (po *ProcessOrders) MarketChange() (float64, bool) {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	type quote struct {
+		change float64
+		index  bool
+		ok     bool
+	}
+
+	indexChange, indexFound := 0.0, false
+	sum, count := 0.0, 0
+	for _, sh := range po.shards {
+		if !sh.Running() {
+			continue
+		}
+		sh := sh
+		result := make(chan quote, 1)
+		sh.runTask(func() {
+			reference := sh.params.ReferencePrice
+			if reference <= 0 || sh.Depth.LastPrice <= 0 {
+				result <- quote{}
+				return
+			}
+			result <- quote{
+				change: float64(sh.Depth.LastPrice-reference) / float64(reference) * 100,
+				index:  sh.params.Index,
+				ok:     true,
+			}
+		})
+
+		q := <-result
+		if !q.ok {
+			continue
+		}
+		if q.index {
+			if !indexFound || q.change < indexChange {
+				indexChange = q.change
+			}
+			indexFound = true
+		}
+		sum += q.change
+		count++
+	}
+
+	if indexFound {
+		return indexChange, true
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// notifyResume 거래 재개 5분 전, 1분 전 알림 (1분마다 호출)
+func notifyResume() {
+	halt := exchanges.GetHalt()
+	if !halt.Halted || halt.ResumeAt == 0 {
+		return
+	}
+
+	left := time.Until(time.UnixMilli(halt.ResumeAt))
+	switch {
+	case left > 4*time.Minute && left <= 5*time.Minute:
+		broadcastSessionStatus(t.SessionStatus{Session: "resume-5m", ResumeAt: halt.ResumeAt})
+	case left > 0 && left <= time.Minute:
+		broadcastSessionStatus(t.SessionStatus{Session: "resume-1m", ResumeAt: halt.ResumeAt})
+	}
+}
+
+func broadcastSessionStatus(status t.SessionStatus) {
+	ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), ws.SessionPayload(status))
+}
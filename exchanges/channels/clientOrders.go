@@ -0,0 +1,125 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"sync"
+)
+
+const maxClientOrderIDLength = 64
+
+// ClientOrder client_order_id 로 찾은 주문
+type ClientOrder struct {
+	Symbol  string `json:"symbol"`
+	OrderID string `json:"order_id"`
+}
+
+type clientOrderKey struct {
+	userID        int
+	clientOrderID string
+}
+
+var (
+	clientOrders    = make(map[clientOrderKey]ClientOrder) // 오늘 사용된 client_order_id (거절된 주문 포함, 장 시작 전 초기화)
+	clientOrderLock sync.RWMutex
+)
+
+// validClientOrderID 최대 64자, 영문, 숫자, - _ . : 만 허용
+func validClientOrderID(clientOrderID string) bool {
+	if len(clientOrderID) > maxClientOrderIDLength {
+		return false
+	}
+	for _, r := range clientOrderID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// PrepareClientOrder 매칭 엔진에 보내기 전 client_order_id 처리 (신규 주문은 ID 예약, 정정 / 취소는 order_id 로 변환)
+// 실패하면 응답 코드, 거절 사유 코드, 메시지와 중복된 경우 기존 주문을 반환
+func PrepareClientOrder(orderReq *t.OrderRequest) (int, string, string, *ClientOrder) {
+	if orderReq.ClientOrderID == "" {
+		return 0, "", "", nil
+	}
+	if !validClientOrderID(orderReq.ClientOrderID) {
+		return 400, t.ReasonInvalidClientOrderID, "Invalid ClientOrderID", nil
+	}
+
+	key := clientOrderKey{userID: orderReq.UserID, clientOrderID: orderReq.ClientOrderID}
+	if orderReq.Status != t.StatusOpen {
+		// order_id 를 함께 보낸 경우 order_id 우선
+		if orderReq.OrderID != "" {
+			return 0, "", "", nil
+		}
+		clientOrderLock.RLock()
+		order, ok := clientOrders[key]
+		clientOrderLock.RUnlock()
+		if !ok || order.Symbol != orderReq.Symbol {
+			return 400, t.ReasonInvalidOrderID, "Unknown ClientOrderID", nil
+		}
+		orderReq.OrderID = order.OrderID
+		return 0, "", "", nil
+	}
+
+	clientOrderLock.Lock()
+	defer clientOrderLock.Unlock()
+	if order, ok := clientOrders[key]; ok {
+		return 409, t.ReasonDuplicateClientOrderID, "ClientOrderID already used today", &order
+	}
+	clientOrders[key] = ClientOrder{Symbol: orderReq.Symbol, OrderID: orderReq.OrderID}
+	return 0, "", "", nil
+}
+
+// ReleaseClientOrder 매칭 엔진에 전달하지 못한 신규 주문의 client_order_id 예약 취소
+func ReleaseClientOrder(orderReq *t.OrderRequest) {
+	if orderReq.ClientOrderID == "" || orderReq.Status != t.StatusOpen {
+		return
+	}
+	clientOrderLock.Lock()
+	defer clientOrderLock.Unlock()
+	key := clientOrderKey{userID: orderReq.UserID, clientOrderID: orderReq.ClientOrderID}
+	if order, ok := clientOrders[key]; ok && order.OrderID == orderReq.OrderID {
+		delete(clientOrders, key)
+	}
+}
+
+// LookupClientOrder 오늘 사용된 client_order_id 의 주문 조회
+func LookupClientOrder(userID int, clientOrderID string) (ClientOrder, bool) {
+	clientOrderLock.RLock()
+	defer clientOrderLock.RUnlock()
+	order, ok := clientOrders[clientOrderKey{userID: userID, clientOrderID: clientOrderID}]
+	return order, ok
+}
+
+// rememberClientOrder 복구된 주문의 client_order_id 다시 등록 (이미 등록된 경우 유지)
+func rememberClientOrder(userID int, clientOrderID, symbol, orderID string) {
+	if clientOrderID == "" {
+		return
+	}
+	clientOrderLock.Lock()
+	defer clientOrderLock.Unlock()
+	key := clientOrderKey{userID: userID, clientOrderID: clientOrderID}
+	if _, ok := clientOrders[key]; !ok {
+		clientOrders[key] = ClientOrder{Symbol: symbol, OrderID: orderID}
+	}
+}
+
+// ClearClientOrders 하루 동안 사용된 client_order_id 초기화 (GTC, GTD 주문으로 남아있는 주문은 유지)
+func (po *ProcessOrders) ClearClientOrders() {
+	clientOrderLock.Lock()
+	clientOrders = make(map[clientOrderKey]ClientOrder)
+	clientOrderLock.Unlock()
+
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+	for _, sh := range po.shards {
+		sh.runTask(func() {
+			for orderID, exec := range sh.Depth.Executions {
+				rememberClientOrder(exec.UserID, exec.ClientOrderID, sh.Symbol, orderID)
+			}
+		})
+	}
+}
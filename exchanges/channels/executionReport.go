@@ -0,0 +1,322 @@
+package channels
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/routes/ws"
+	t "PJS_Exchange/template"
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const execSeqBlock = 1000 // 한 번에 DB에 예약할 실행 보고서 번호 개수
+
+var (
+	execSeqs    = make(map[int]*execSeq) // 사용자별 실행 보고서 번호
+	execSeqLock sync.Mutex
+
+	reportListeners    []func(report t.ExecutionReport) // WebSocket 이외의 실행 보고서 수신자 (FIX 등)
+	reportListenerLock sync.RWMutex
+)
+
+// AddExecutionReportListener 실행 보고서 수신 함수 등록 (매칭 엔진 고루틴에서 호출되므로 오래 걸리는 작업은 하지 말 것)
+func AddExecutionReportListener(listener func(report t.ExecutionReport)) {
+	reportListenerLock.Lock()
+	defer reportListenerLock.Unlock()
+	reportListeners = append(reportListeners, listener)
+}
+
+// execSeq 사용자 한 명의 실행 보고서 번호
+type execSeq struct {
+	lock     sync.Mutex // 번호 부여부터 전송까지 잡아서 여러 심볼의 매칭 엔진이 보내도 번호 순서대로 전달
+	last     int64      // 마지막으로 부여한 번호
+	reserved int64      // DB에 예약한 마지막 번호 (재시작하면 이 다음 번호부터 사용)
+}
+
+func userExecSeq(userID int) *execSeq {
+	execSeqLock.Lock()
+	defer execSeqLock.Unlock()
+	seq, ok := execSeqs[userID]
+	if !ok {
+		seq = &execSeq{}
+		execSeqs[userID] = seq
+	}
+	return seq
+}
+
+// loadExecSeqs 서버 재시작 후에도 번호가 이어지도록 저장된 마지막 번호 불러오기 (예약한 번호는 이미 보냈을 수 있으므로 건너뜀)
+func loadExecSeqs() {
+	seqs, err := postgresApp.Get().OrderEventRepo().GetLastSeqs(context.Background())
+	if err != nil {
+		log.Printf("Error loading execution report sequences: %v", err)
+		return
+	}
+
+	for userID, last := range seqs {
+		seq := userExecSeq(userID)
+		seq.lock.Lock()
+		if last > seq.last {
+			seq.last, seq.reserved = last, last
+		}
+		seq.lock.Unlock()
+	}
+}
+
+// next 다음 번호 부여 (seq.lock 을 잡은 상태에서 호출할 것)
+// 예약한 번호를 다 쓰면 DB에 다음 묶음을 먼저 예약해서 재시작 후에도 보낸 번호를 다시 쓰지 않음
+func (seq *execSeq) next(userID int) int64 {
+	if seq.last >= seq.reserved {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		err := postgresApp.Get().OrderEventRepo().ReserveSeq(ctx, userID, seq.last+execSeqBlock)
+		cancel()
+		if err != nil {
+			log.Printf("Error reserving execution report sequences for user %d: %v", userID, err)
+		} else {
+			seq.reserved = seq.last + execSeqBlock
+		}
+	}
+	seq.last++
+	return seq.last
+}
+
+// executionReport 주문의 현재 체결 상태로 실행 보고서 생성
+func executionReport(symbol, orderID string, exec *t.OrderExecution, execType string) t.ExecutionReport {
+	report := t.ExecutionReport{
+		Timestamp:       time.Now().UnixMilli(),
+		UserID:          exec.UserID,
+		ExecType:        execType,
+		OrderID:         orderID,
+		ClientOrderID:   exec.ClientOrderID,
+		Symbol:          symbol,
+		Side:            exec.Side,
+		OrderType:       exec.OrderType,
+		Price:           exec.Price,
+		StopPrice:       exec.StopPrice,
+		TimeInForce:     exec.TimeInForce,
+		ExpireAt:        exec.ExpireAt,
+		PostOnly:        exec.PostOnly,
+		DisplayQuantity: exec.DisplayQuantity,
+		Quantity:        exec.Quantity,
+		CumQuantity:     exec.CumQuantity,
+		LeavesQuantity:  max(exec.Quantity-exec.CumQuantity, 0),
+	}
+	if exec.CumQuantity > 0 {
+		report.AvgPrice = float64(exec.CumAmount) / float64(exec.CumQuantity)
+	}
+
+	switch {
+	case report.LeavesQuantity == 0 && exec.CumQuantity > 0:
+		report.Status = t.StatusFilled
+	case exec.CumQuantity > 0:
+		report.Status = t.StatusPartiallyFilled
+	default:
+		report.Status = t.StatusOpen
+	}
+	return report
+}
+
+// orderExecution 체결 상태가 없는 주문(접수 전 거절, 복구 중 취소 등)은 요청 정보로 대신함
+func orderExecution(depth *t.MarketDepth, orderReq *t.OrderRequest) *t.OrderExecution {
+	if exec, ok := depth.Executions[orderReq.OrderID]; ok {
+		return exec
+	}
+	return requestExecution(orderReq)
+}
+
+// requestExecution 요청 정보만으로 만든 체결 상태 (order_id, client_order_id 도 요청한 값 그대로 사용)
+func requestExecution(orderReq *t.OrderRequest) *t.OrderExecution {
+	return &t.OrderExecution{
+		UserID:        orderReq.UserID,
+		ClientOrderID: orderReq.ClientOrderID,
+		Side:          orderReq.Side,
+		OrderType:     orderReq.OrderType,
+		Price:         orderReq.Price,
+		TimeInForce:   orderReq.TimeInForce,
+		ExpireAt:      orderReq.ExpireAt,
+		Quantity:      orderReq.Quantity,
+	}
+}
+
+// reportAccepted 접수된 주문(신규, 정정, 취소) 알림
+func reportAccepted(depth *t.MarketDepth, orderReq *t.OrderRequest) {
+	switch orderReq.Status {
+	case t.StatusOpen:
+		exec := &t.OrderExecution{
+			UserID:              orderReq.UserID,
+			ClientOrderID:       orderReq.ClientOrderID,
+			Side:                orderReq.Side,
+			OrderType:           orderReq.OrderType,
+			Price:               orderReq.Price,
+			StopPrice:           orderReq.StopPrice,
+			TimeInForce:         orderReq.TimeInForce,
+			ExpireAt:            orderReq.ExpireAt,
+			PostOnly:            orderReq.PostOnly,
+			DisplayQuantity:     orderReq.DisplayQuantity,
+			Quantity:            orderReq.Quantity,
+			SelfTradePrevention: orderReq.SelfTradePrevention,
+		}
+		depth.Executions[orderReq.OrderID] = exec
+		rememberClientOrder(orderReq.UserID, orderReq.ClientOrderID, orderReq.Symbol, orderReq.OrderID)
+		sendExecutionReport(executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeNew))
+	case t.StatusModified:
+		// 정정 후 주문 수량 = 이미 체결된 수량 + 정정 수량
+		exec := orderExecution(depth, orderReq)
+		exec.OrderType = orderReq.OrderType
+		exec.Price = orderReq.Price
+		exec.StopPrice = orderReq.StopPrice
+		exec.PostOnly = orderReq.PostOnly
+		exec.Quantity = exec.CumQuantity + orderReq.Quantity
+		depth.Executions[orderReq.OrderID] = exec
+		sendExecutionReport(executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeReplace))
+	case t.StatusCanceled:
+		reason := "Canceled by user"
+		if orderReq.CancelReason != "" {
+			reason = orderReq.CancelReason
+		}
+		reportCancel(depth, orderReq, reason)
+	}
+}
+
+// reportTriggered 스탑 주문 발동 알림 (발동 후에는 시장가 / 지정가 주문으로 처리)
+func reportTriggered(depth *t.MarketDepth, orderReq *t.OrderRequest) {
+	exec := orderExecution(depth, orderReq)
+	exec.OrderType = orderReq.OrderType
+	exec.Price = orderReq.Price
+	exec.TimeInForce = orderReq.TimeInForce
+	depth.Executions[orderReq.OrderID] = exec
+	sendExecutionReport(executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeTriggered))
+}
+
+// reportFill 체결 알림 (주문이 전부 체결되면 체결 상태 삭제)
+func reportFill(depth *t.MarketDepth, symbol, orderID string, price t.Price, quantity int, executionID string) {
+	exec, ok := depth.Executions[orderID]
+	if !ok {
+		log.Printf("Missing execution state for order %s (%s)", orderID, symbol)
+		return
+	}
+	exec.CumQuantity += quantity
+	exec.CumAmount += int64(price) * int64(quantity)
+
+	execType := t.ExecTypePartial
+	if exec.CumQuantity >= exec.Quantity {
+		execType = t.ExecTypeFill
+		delete(depth.Executions, orderID)
+	}
+
+	report := executionReport(symbol, orderID, exec, execType)
+	report.ExecutionID = executionID
+	report.LastPrice = price
+	report.LastQuantity = quantity
+	sendExecutionReport(report)
+}
+
+// reportCancel 주문 취소 알림 (남은 수량은 모두 취소되므로 체결 상태 삭제)
+func reportCancel(depth *t.MarketDepth, orderReq *t.OrderRequest, reason string) {
+	exec := orderExecution(depth, orderReq)
+	delete(depth.Executions, orderReq.OrderID)
+
+	report := executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeCancel)
+	report.Status = t.StatusCanceled
+	report.LeavesQuantity = 0
+	report.Reason = reason
+	sendExecutionReport(report)
+}
+
+// reportReject 주문 거절 알림 (정정, 취소 요청이 거절된 경우 기존 주문은 그대로 유지, 다른 사용자의 주문 정보는 포함하지 않음)
+func reportReject(depth *t.MarketDepth, orderReq *t.OrderRequest, reasonCode, reason string) {
+	if orderReq.OrderID == "" {
+		return
+	}
+
+	var report t.ExecutionReport
+	exec, ok := depth.Executions[orderReq.OrderID]
+	if ok && orderReq.Status != t.StatusOpen && exec.UserID == orderReq.UserID {
+		report = executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeReject)
+	} else {
+		// 주문이 없거나 다른 사용자의 주문이면 요청 정보로만 알림
+		if !ok || exec.UserID != orderReq.UserID {
+			exec = requestExecution(orderReq)
+		}
+		report = executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeReject)
+		report.Status = t.StatusRejected
+		report.LeavesQuantity = 0
+	}
+	report.UserID = orderReq.UserID
+	report.Reason = reason
+	report.ReasonCode = reasonCode
+	sendExecutionReport(report)
+}
+
+// reportSelfTrade 자기매매 방지로 취소되거나 수량이 줄어든 주문 알림 (counterOrderID: 체결될 뻔한 내 반대편 주문)
+func reportSelfTrade(depth *t.MarketDepth, orderReq *t.OrderRequest, mode, counterOrderID string, canceled bool) {
+	exec := orderExecution(depth, orderReq)
+
+	var report t.ExecutionReport
+	if canceled {
+		delete(depth.Executions, orderReq.OrderID)
+		report = executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeCancel)
+		report.Status = t.StatusCanceled
+		report.LeavesQuantity = 0
+	} else {
+		report = executionReport(orderReq.Symbol, orderReq.OrderID, exec, t.ExecTypeRestated)
+	}
+	report.Reason = "Self-trade prevented (" + mode + ") against own order " + counterOrderID
+	report.ReasonCode = t.ReasonSelfTradePrevention
+	sendExecutionReport(report)
+}
+
+func sendExecutionReport(report t.ExecutionReport) {
+	// 복구 중에는 이미 전송된 알림이므로 생략
+	if isReplaying(report.Symbol) {
+		return
+	}
+	seq := userExecSeq(report.UserID)
+	seq.lock.Lock()
+	defer seq.lock.Unlock()
+	report.Seq = seq.next(report.UserID)
+
+	// 두 허브가 같은 메시지를 공유해서 인코딩별로 한 번만 직렬화
+	payload := ws.NotifyPayload(report)
+	ws.NotifyHub.SendMessageToUser(report.UserID, report.Timestamp, payload)
+	ws.TradeHub.SendMessageToUser(report.UserID, report.Timestamp, payload)
+
+	reportListenerLock.RLock()
+	for _, listener := range reportListeners {
+		listener(report)
+	}
+	reportListenerLock.RUnlock()
+
+	recordOrderEvent(report)
+}
+
+// recordOrderEvent 주문 이벤트 DB 저장 (비동기, 복구 중에는 저장하지 않음)
+func recordOrderEvent(report t.ExecutionReport) {
+	if EW != nil && shouldPersist(report.Symbol) {
+		EW.Add(postgresql.OrderEvent{
+			Seq:            report.Seq,
+			Timestamp:      time.UnixMilli(report.Timestamp),
+			UserID:         report.UserID,
+			ExecType:       report.ExecType,
+			ExecutionID:    report.ExecutionID,
+			OrderID:        report.OrderID,
+			ClientOrderID:  report.ClientOrderID,
+			Symbol:         report.Symbol,
+			Side:           report.Side,
+			OrderType:      report.OrderType,
+			Status:         report.Status,
+			Price:          int64(report.Price),
+			StopPrice:      int64(report.StopPrice),
+			TimeInForce:    report.TimeInForce,
+			Quantity:       report.Quantity,
+			LastPrice:      int64(report.LastPrice),
+			LastQuantity:   report.LastQuantity,
+			CumQuantity:    report.CumQuantity,
+			LeavesQuantity: report.LeavesQuantity,
+			AvgPrice:       report.AvgPrice,
+			Reason:         report.Reason,
+			ReasonCode:     report.ReasonCode,
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// normalizeDisplayQuantity 신규 주문의 아이스버그 노출 수량 검증 (지정가 주문만, 전체 수량 이상이면 일반 주문)
+func normalizeDisplayQuantity(orderReq *t.OrderRequest) (string, string) {
+	if orderReq.DisplayQuantity == 0 {
+		return "", ""
+	}
+	if orderReq.DisplayQuantity < 0 {
+		return t.ReasonInvalidQuantity, "Invalid DisplayQuantity"
+	}
+	if orderReq.OrderType != t.OrderTypeLimit {
+		return t.ReasonInvalidQuantity, "DisplayQuantity is only supported for limit orders"
+	}
+	if orderReq.DisplayQuantity >= orderReq.Quantity {
+		orderReq.DisplayQuantity = 0
+	}
+	return "", ""
+}
+
+// visibleOrder 아이스버그 주문은 노출 수량만 호가에 올리도록 수량을 줄인 사본 반환 (일반 주문은 그대로)
+func visibleOrder(orderReq *t.OrderRequest, depth *t.MarketDepth) *t.OrderRequest {
+	displayQuantity := orderReq.DisplayQuantity
+	if exec, ok := depth.Executions[orderReq.OrderID]; ok {
+		displayQuantity = exec.DisplayQuantity
+	}
+	if displayQuantity <= 0 || displayQuantity >= orderReq.Quantity {
+		return orderReq
+	}
+	visible := *orderReq
+	visible.Quantity = displayQuantity
+	return &visible
+}
+
+// leavesQuantity 호가에 남아있는 주문의 남은 수량 (아이스버그 주문은 숨겨진 수량 포함)
+func leavesQuantity(orderID string, depth *t.MarketDepth, depthIndex *map[string][]interface{}) int {
+	if exec, ok := depth.Executions[orderID]; ok && exec.DisplayQuantity > 0 {
+		return exec.Quantity - exec.CumQuantity
+	}
+	return (*depthIndex)[orderID][3].(int)
+}
+
+// replenishIceberg 노출 수량이 모두 체결된 아이스버그 주문을 숨겨진 수량으로 다시 채움 (가격대 주문 순서 맨 뒤로, 최초 접수 시각은 유지)
+func replenishIceberg(symbol, orderID, side string, price t.Price, createdAt int64, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
+	exec, ok := depth.Executions[orderID]
+	if !ok || exec.DisplayQuantity <= 0 {
+		// 일반 주문이거나 전부 체결된 주문
+		return
+	}
+	leaves := exec.Quantity - exec.CumQuantity
+	if leaves <= 0 {
+		return
+	}
+	if index := (*depthIndex)[orderID]; index != nil {
+		if index[3].(int) > 0 {
+			// 아직 노출 수량이 남아있음
+			return
+		}
+		processCancel(&t.OrderRequest{OrderID: orderID, Side: side}, depth, depthIndex, bidAskOverLab, executionSeq)
+	}
+
+	processOpen(&t.OrderRequest{
+		Timestamp: time.Now().UnixMilli(),
+		UserID:    exec.UserID,
+		OrderID:   orderID,
+		Symbol:    symbol,
+		Status:    t.StatusOpen,
+		Side:      side,
+		OrderType: t.OrderTypeLimit,
+		Price:     price,
+		Quantity:  min(exec.DisplayQuantity, leaves),
+	}, depth, depthIndex, bidAskOverLab, executionSeq)
+	keepOrderInfo(orderID, t.Order{CreatedAt: createdAt}, depth, depthIndex)
+}
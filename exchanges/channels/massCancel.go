@@ -0,0 +1,66 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"log"
+	"sort"
+)
+
+// CancelOrders 조건에 맞는 주문(발동 대기 중인 스탑 주문 포함) 일괄 취소, 취소된 주문 ID 반환
+// symbol 이 빈 값이면 모든 심볼, userID 가 0이면 모든 사용자, side 가 빈 값이면 매수 / 매도 모두
+// 심볼별로 매칭 고루틴 안에서 한 번에 처리하므로 취소하는 동안 다른 주문이 끼어들지 않음
+func (po *ProcessOrders) CancelOrders(symbol string, userID int, side, reason string) []string {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	canceled := make([]string, 0)
+	for sym, sh := range po.shards {
+		if symbol != "" && sym != symbol {
+			continue
+		}
+		sh := sh
+		result := make(chan []string, 1)
+		sh.runTask(func() {
+			result <- sh.cancelOrders(userID, side, reason)
+		})
+		canceled = append(canceled, <-result...)
+	}
+	return canceled
+}
+
+// cancelOrders 조건에 맞는 주문을 취소 요청으로 처리 (저널에 기록되므로 복구 시에도 같은 순서로 취소됨)
+func (sh *SymbolShard) cancelOrders(userID int, side, reason string) []string {
+	var targets []string
+	for orderID, exec := range sh.Depth.Executions {
+		if sh.DepthOrderIDIndex[orderID] == nil && sh.Stops.Orders[orderID] == nil {
+			continue
+		}
+		if (userID != 0 && exec.UserID != userID) || (side != "" && exec.Side != side) {
+			continue
+		}
+		targets = append(targets, orderID)
+	}
+	sort.Strings(targets)
+
+	canceled := make([]string, 0, len(targets))
+	for _, orderID := range targets {
+		exec := sh.Depth.Executions[orderID]
+		orderReq := t.OrderRequest{
+			UserID:       exec.UserID,
+			OrderID:      orderID,
+			Symbol:       sh.Symbol,
+			Status:       t.StatusCanceled,
+			Side:         exec.Side,
+			CancelReason: reason,
+			ResultChan:   make(chan t.Result, 1),
+		}
+		sh.processOrderRequest(orderReq)
+		if result := <-orderReq.ResultChan; result.Success {
+			canceled = append(canceled, orderID)
+		}
+	}
+	if len(canceled) > 0 {
+		log.Printf("Mass canceled %d orders for %s", len(canceled), sh.Symbol)
+	}
+	return canceled
+}
@@ -0,0 +1,136 @@
+package channels
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
+	s "PJS_Exchange/middlewares/symbol"
+	t "PJS_Exchange/template"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TradableCheck 심볼 주문 가능 여부 확인 결과 (code 가 0이면 주문 가능)
+type TradableCheck struct {
+	Code    int
+	Message string
+}
+
+func rejectedResult(code int, reasonCode, message string) t.Result {
+	return t.Result{
+		Timestamp:  time.Now().UnixMilli(),
+		Success:    false,
+		Message:    message,
+		Code:       code,
+		ReasonCode: reasonCode,
+	}
+}
+
+// SubmitOrder client_order_id 처리 후 매칭 엔진 대기열에 주문 요청 전달 (전달되면 결과는 ResultChan 으로 수신)
+// 전달하지 못하면 거절 결과와 false 반환, 이미 사용한 client_order_id 면 orderReq.OrderID 를 기존 주문 ID로 바꿈
+func (po *ProcessOrders) SubmitOrder(orderReq *t.OrderRequest) (t.Result, bool) {
+	if code, reasonCode, message, existing := PrepareClientOrder(orderReq); code != 0 {
+		if existing != nil {
+			orderReq.OrderID = existing.OrderID
+		}
+		return rejectedResult(code, reasonCode, message), false
+	}
+
+	select {
+	case po.OrderRequestChan <- *orderReq:
+		return t.Result{}, true
+	case <-time.After(5 * time.Second):
+		ReleaseClientOrder(orderReq) // 매칭 엔진에 전달되지 않았으므로 다시 사용 가능
+		return rejectedResult(503, "", "Order processing is busy, please try again later"), false
+	}
+}
+
+// SubmitItem 일괄 주문, 거래 WebSocket 의 개별 요청을 검증해서 매칭 엔진에 전달 (API 키 권한, 방향, 거래 중단, 심볼 상태 확인)
+// checked 가 nil 이 아니면 심볼 상태 확인 결과를 저장해서 같은 심볼은 한 번만 확인
+func (po *ProcessOrders) SubmitItem(ctx context.Context, item t.BatchOrderItem, user *postgresql.User, apiKey *postgresql.APIKey, checked map[string]TradableCheck) (*t.OrderRequest, t.Result, bool) {
+	orderReq := &t.OrderRequest{
+		UserID:              user.ID,
+		OrderID:             item.OrderID,
+		ClientOrderID:       item.ClientOrderID,
+		Symbol:              item.Symbol,
+		Side:                item.Side,
+		OrderType:           item.OrderType,
+		Price:               item.Price,
+		StopPrice:           item.StopPrice,
+		Quantity:            item.Quantity,
+		TimeInForce:         item.TimeInForce,
+		ExpireAt:            item.ExpireAt,
+		PostOnly:            item.PostOnly,
+		DisplayQuantity:     item.DisplayQuantity,
+		SelfTradePrevention: item.SelfTradePrevention,
+		ResultChan:          make(chan t.Result, 1),
+	}
+
+	var scope postgresql.APIKeyScope
+	switch item.Action {
+	case t.BatchActionNew:
+		orderReq.Status, scope = t.StatusOpen, postgresql.APIKeyScope{OrderCreate: true}
+	case t.BatchActionModify:
+		orderReq.Status, scope = t.StatusModified, postgresql.APIKeyScope{OrderModify: true}
+	case t.BatchActionCancel:
+		orderReq.Status, scope = t.StatusCanceled, postgresql.APIKeyScope{OrderCancel: true}
+	default:
+		return orderReq, rejectedResult(400, "", "Invalid action"), false
+	}
+	if !postgresql.IsinScope(apiKey.Scopes, scope) {
+		return orderReq, rejectedResult(403, "", "Insufficient scope for "+item.Action), false
+	}
+	if item.Side != t.SideBuy && item.Side != t.SideSell {
+		return orderReq, rejectedResult(400, "", "Invalid side"), false
+	}
+	if exchanges.MarketStatus == "closed" {
+		return orderReq, rejectedResult(503, "", "Market is closed"), false
+	}
+	if exchanges.IsHalted() && orderReq.Status != t.StatusCanceled {
+		return orderReq, rejectedResult(503, t.ReasonMarketHalted, "Trading is halted"), false
+	}
+
+	// 취소는 변동성 완화장치 발동 중에도 가능하므로 따로 확인
+	cancel := orderReq.Status == t.StatusCanceled
+	key := item.Symbol
+	if cancel {
+		key += ":cancel"
+	}
+	check, ok := checked[key]
+	if !ok {
+		_, check.Code, check.Message, _ = s.CheckTradable(ctx, item.Symbol, cancel)
+		if checked != nil {
+			checked[key] = check
+		}
+	}
+	if check.Code != 0 {
+		return orderReq, rejectedResult(check.Code, "", check.Message), false
+	}
+
+	// 서버 측에서 설정 (자기매매 방지 모드를 지정하지 않으면 사용자 기본값 사용)
+	if orderReq.Status == t.StatusOpen {
+		orderReq.OrderID = uuid.NewString()
+		if orderReq.SelfTradePrevention == "" {
+			orderReq.SelfTradePrevention = user.SelfTradePrevention
+		}
+	}
+
+	result, ok := po.SubmitOrder(orderReq)
+	return orderReq, result, ok
+}
+
+// WaitResult 매칭 엔진의 처리 결과 대기 (이미 도착한 결과는 대기 시간이 지났어도 반환)
+func WaitResult(ctx context.Context, resultChan chan t.Result) (t.Result, bool) {
+	select {
+	case result := <-resultChan:
+		return result, true
+	default:
+	}
+	select {
+	case result := <-resultChan:
+		return result, true
+	case <-ctx.Done():
+		return t.Result{}, false
+	}
+}
@@ -0,0 +1,242 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	JournalEntryOrder = "order" // 접수된 주문 (체결 처리 전에 기록)
+	JournalEntryDone  = "done"  // 주문 처리 완료
+	JournalEntryPhase = "phase" // 단일가 매매 단계 변경 (단계가 끝나면 단일가 체결)
+)
+
+var (
+	replayingSymbols sync.Map // 복구 중인 심볼 (복구 중에는 알림/브로드캐스트, DB 저장 생략)
+)
+
+type JournalEntry struct {
+	Seq   int64           `json:"seq"`
+	Type  string          `json:"type"` // "order", "done" or "phase"
+	Order *t.OrderRequest `json:"order,omitempty"`
+	Phase string          `json:"phase,omitempty"` // 변경된 단일가 매매 단계 ("" 이면 접속 매매)
+}
+
+type SnapshotOrder struct {
+	OrderID             string  `json:"order_id"`
+	ClientOrderID       string  `json:"client_order_id,omitempty"`
+	UserID              int     `json:"user_id"`
+	Price               t.Price `json:"price"`
+	Quantity            int     `json:"quantity"`                 // 남은 수량
+	OrderQuantity       int     `json:"order_quantity,omitempty"` // 주문(정정) 수량
+	CumQuantity         int     `json:"cum_quantity,omitempty"`
+	CumAmount           int64   `json:"cum_amount,omitempty"`
+	TimeInForce         string  `json:"time_in_force,omitempty"`
+	ExpireAt            int64   `json:"expire_at,omitempty"`
+	PostOnly            string  `json:"post_only,omitempty"`
+	DisplayQuantity     int     `json:"display_quantity,omitempty"`
+	SelfTradePrevention string  `json:"self_trade_prevention,omitempty"`
+	CreatedAt           int64   `json:"created_at,omitempty"`
+}
+
+// BookSnapshot 호가 스냅샷 (가격 우선, 시간 우선 순서로 저장)
+type BookSnapshot struct {
+	Timestamp int64           `json:"timestamp"`
+	Symbol    string          `json:"symbol"`
+	Seq       int64           `json:"seq"` // 스냅샷에 반영된 마지막 저널 번호
+	Bids      []SnapshotOrder `json:"bids"`
+	Asks      []SnapshotOrder `json:"asks"`
+	LastPrice t.Price         `json:"last_price,omitempty"` // 최종 체결가 (스탑 주문 발동 기준)
+	Stops     []StopOrder     `json:"stops,omitempty"`      // 발동 대기 중인 스탑 주문 (발동 순서)
+	Auction   string          `json:"auction,omitempty"`    // 진행 중인 단일가 매매 단계 ("opening", "closing", "volatility", "halt")
+}
+
+// OrderJournal 심볼별 주문 저널 (append-only) + 호가 스냅샷
+type OrderJournal struct {
+	path         string
+	snapshotPath string
+	file         *os.File
+	seq          int64
+}
+
+func journalDir() string {
+	return utils.GetEnv("JOURNAL_LOCATION", "./journal")
+}
+
+func journalSnapshotInterval() time.Duration {
+	sec, err := strconv.Atoi(utils.GetEnv("JOURNAL_SNAPSHOT_INTERVAL", "300"))
+	if err != nil || sec <= 0 {
+		sec = 300
+	}
+	return time.Duration(sec) * time.Second
+}
+
+func OpenOrderJournal(symbol string) (*OrderJournal, error) {
+	dir := journalDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(symbol)
+	j := &OrderJournal{
+		path:         filepath.Join(dir, name+".journal"),
+		snapshotPath: filepath.Join(dir, name+".snapshot.json"),
+	}
+
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	j.file = file
+	return j, nil
+}
+
+func (j *OrderJournal) append(entry JournalEntry, sync bool) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := j.file.Write(data); err != nil {
+		return err
+	}
+	if sync {
+		return j.file.Sync()
+	}
+	return nil
+}
+
+// AppendOrder 주문을 저널에 기록 (디스크 동기화 후 반환)
+func (j *OrderJournal) AppendOrder(orderReq t.OrderRequest) (int64, error) {
+	j.seq++
+	err := j.append(JournalEntry{
+		Seq:   j.seq,
+		Type:  JournalEntryOrder,
+		Order: &orderReq,
+	}, true)
+	return j.seq, err
+}
+
+// AppendPhase 단일가 매매 단계 변경을 저널에 기록 (디스크 동기화 후 반환)
+func (j *OrderJournal) AppendPhase(phase string) (int64, error) {
+	j.seq++
+	err := j.append(JournalEntry{
+		Seq:   j.seq,
+		Type:  JournalEntryPhase,
+		Phase: phase,
+	}, true)
+	return j.seq, err
+}
+
+// AppendDone 주문 처리 완료 기록 (유실되어도 복구 시 다시 처리되므로 동기화 생략)
+func (j *OrderJournal) AppendDone(seq int64) error {
+	return j.append(JournalEntry{
+		Seq:  seq,
+		Type: JournalEntryDone,
+	}, false)
+}
+
+// ReadEntries 저널의 모든 기록 읽기
+func (j *OrderJournal) ReadEntries() ([]JournalEntry, error) {
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// 기록 도중 종료되어 잘린 마지막 줄은 무시
+			continue
+		}
+		entries = append(entries, entry)
+		if entry.Seq > j.seq {
+			j.seq = entry.Seq
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// WriteSnapshot 스냅샷을 저장하고 스냅샷에 반영된 저널 비우기
+func (j *OrderJournal) WriteSnapshot(snapshot BookSnapshot) error {
+	snapshot.Seq = j.seq
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	// 임시 파일에 쓴 뒤 교체 (저장 도중 종료되어도 이전 스냅샷 유지)
+	tmpPath := j.snapshotPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, j.snapshotPath); err != nil {
+		return err
+	}
+	return j.file.Truncate(0)
+}
+
+// ReadSnapshot 마지막 스냅샷 읽기 (없으면 nil)
+func (j *OrderJournal) ReadSnapshot() (*BookSnapshot, error) {
+	data, err := os.ReadFile(j.snapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot BookSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Seq > j.seq {
+		j.seq = snapshot.Seq
+	}
+	return &snapshot, nil
+}
+
+// Reset 저널과 스냅샷 모두 삭제 (일일 초기화)
+func (j *OrderJournal) Reset() error {
+	if err := os.Remove(j.snapshotPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	j.seq = 0
+	return j.file.Truncate(0)
+}
+
+// Remove 저널 파일 삭제 (상장 폐지)
+func (j *OrderJournal) Remove() error {
+	if err := j.Reset(); err != nil {
+		return err
+	}
+	_ = j.file.Close()
+	return os.Remove(j.path)
+}
+
+func (j *OrderJournal) Close() error {
+	return j.file.Close()
+}
+
+func isReplaying(symbol string) bool {
+	_, ok := replayingSymbols.Load(symbol)
+	return ok
+}
+
+// shouldPersist 체결, 주문 이벤트를 DB에 저장할지 여부
+// 복구 중 저널을 다시 처리하며 생긴 체결, 주문 이벤트는 장애 전에 이미 저장된 것이므로 저장하지 않음 (체결 ID가 같게 재생성되는 것에 의존하지 않음)
+func shouldPersist(symbol string) bool {
+	return !isReplaying(symbol)
+}
@@ -0,0 +1,130 @@
+package channels
+
+import (
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/template"
+	"reflect"
+	"testing"
+)
+
+// journalBook 비교용 호가 (스냅샷 시각, 저널 번호 제외)
+func journalBook(sh *SymbolShard) BookSnapshot {
+	snapshot := sh.snapshot()
+	snapshot.Timestamp, snapshot.Seq = 0, 0
+	return snapshot
+}
+
+func TestRestoreExchangeReproducesBook(t *testing.T) {
+	// 체결 조건 코드는 세션에서 만들어지므로 정규장으로 설정
+	status := exchanges.MarketStatus
+	exchanges.MarketStatus = "regular"
+	defer func() { exchanges.MarketStatus = status }()
+
+	limit := func(orderID string, userID int, side string, price template.Price, quantity int) template.OrderRequest {
+		return template.OrderRequest{UserID: userID, OrderID: orderID, Side: side, OrderType: template.OrderTypeLimit, Price: price, Quantity: quantity, Status: template.StatusOpen}
+	}
+	modify := func(orderID string, userID int, side string, price template.Price, quantity int) template.OrderRequest {
+		return template.OrderRequest{UserID: userID, OrderID: orderID, Side: side, OrderType: template.OrderTypeLimit, Price: price, Quantity: quantity, Status: template.StatusModified}
+	}
+	cancel := func(orderID string, userID int, side string) template.OrderRequest {
+		return template.OrderRequest{UserID: userID, OrderID: orderID, Side: side, Status: template.StatusCanceled}
+	}
+
+	tests := []struct {
+		name     string
+		requests []template.OrderRequest
+		snapshot int // 이 개수만큼 처리한 뒤 스냅샷 저장 (0이면 저장하지 않음)
+	}{
+		{
+			name: "resting orders",
+			requests: []template.OrderRequest{
+				limit("b1", 1, template.SideBuy, 99, 10),
+				limit("b2", 2, template.SideBuy, 99, 5),
+				limit("a1", 3, template.SideSell, 101, 7),
+			},
+		},
+		{
+			name: "partial fills",
+			requests: []template.OrderRequest{
+				limit("a1", 1, template.SideSell, 100, 10),
+				limit("a2", 2, template.SideSell, 101, 10),
+				limit("b1", 3, template.SideBuy, 101, 15),
+				limit("b2", 4, template.SideBuy, 98, 3),
+			},
+		},
+		{
+			name: "modify and cancel",
+			requests: []template.OrderRequest{
+				limit("b1", 1, template.SideBuy, 99, 10),
+				limit("b2", 2, template.SideBuy, 98, 10),
+				limit("a1", 3, template.SideSell, 102, 10),
+				modify("b2", 2, template.SideBuy, 99, 8),
+				cancel("b1", 1, template.SideBuy),
+				limit("a2", 4, template.SideSell, 99, 3),
+			},
+		},
+		{
+			name:     "snapshot then journal",
+			snapshot: 3,
+			requests: []template.OrderRequest{
+				limit("a1", 1, template.SideSell, 100, 10),
+				limit("a2", 2, template.SideSell, 100, 10),
+				limit("b1", 3, template.SideBuy, 100, 12),
+				limit("b2", 4, template.SideBuy, 97, 4),
+				modify("a2", 2, template.SideSell, 100, 5),
+				limit("b3", 5, template.SideBuy, 100, 2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JOURNAL_LOCATION", t.TempDir())
+			const symbol = "TEST"
+
+			journal, err := OpenOrderJournal(symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 알림, DB 저장 없이 체결 처리 (저널은 매칭 엔진처럼 처리 전후로 기록)
+			live := NewSymbolShard(symbol)
+			replayingSymbols.Store(symbol, true)
+			for i, orderReq := range tt.requests {
+				orderReq.Timestamp = int64(i + 1)
+				orderReq.Symbol = symbol
+				seq, err := journal.AppendOrder(orderReq)
+				if err != nil {
+					t.Fatal(err)
+				}
+				orderReq.ResultChan = make(chan template.Result, 1)
+				live.processOrderRequest(orderReq)
+				if err := journal.AppendDone(seq); err != nil {
+					t.Fatal(err)
+				}
+				if i+1 == tt.snapshot {
+					if err := journal.WriteSnapshot(live.snapshot()); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			replayingSymbols.Delete(symbol)
+			if err := journal.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			journal, err = OpenOrderJournal(symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer journal.Close()
+			restored := NewSymbolShard(symbol)
+			restored.journal = journal
+			restored.RestoreExchange()
+
+			if got, want := journalBook(restored), journalBook(live); !reflect.DeepEqual(got, want) {
+				t.Errorf("restored book = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+)
+
+// normalizePostOnly 신규 주문의 Post-Only 설정 검증 (지정가 주문, IOC / FOK 가 아닌 경우만 가능)
+func normalizePostOnly(orderReq *t.OrderRequest) (string, string) {
+	if orderReq.PostOnly == "" {
+		return "", ""
+	}
+	if orderReq.PostOnly != t.PostOnlyReject && orderReq.PostOnly != t.PostOnlyReprice {
+		return t.ReasonInvalidPostOnly, "Invalid PostOnly"
+	}
+	if orderReq.OrderType != t.OrderTypeLimit {
+		return t.ReasonInvalidPostOnly, "Post-only is only supported for limit orders"
+	}
+	if orderReq.TimeInForce == t.TimeInForceIOC || orderReq.TimeInForce == t.TimeInForceFOK {
+		return t.ReasonInvalidPostOnly, "Post-only orders cannot be IOC or FOK"
+	}
+	return "", ""
+}
+
+// applyPostOnly Post-Only 주문이 반대 호가와 겹치면 거절하거나 한 호가 단위 물러난 가격으로 조정 (통과하면 빈 사유 코드 반환)
+func (sh *SymbolShard) applyPostOnly(orderReq *t.OrderRequest) (string, string) {
+	tickSize := sh.params.TickSize
+	if tickSize <= 0 {
+		tickSize = 1
+	}
+
+	switch orderReq.Side {
+	case t.SideBuy:
+		lowAsk := sh.Depth.AskTree.Min()
+		if lowAsk == nil || t.Price(lowAsk.(t.PriceItem)) > orderReq.Price {
+			return "", ""
+		}
+		if orderReq.PostOnly == t.PostOnlyReprice && t.Price(lowAsk.(t.PriceItem))-tickSize > 0 {
+			orderReq.Price = t.Price(lowAsk.(t.PriceItem)) - tickSize
+			return "", ""
+		}
+	case t.SideSell:
+		highBid := sh.Depth.BidTree.Max()
+		if highBid == nil || t.Price(highBid.(t.PriceItem)) < orderReq.Price {
+			return "", ""
+		}
+		if orderReq.PostOnly == t.PostOnlyReprice {
+			orderReq.Price = t.Price(highBid.(t.PriceItem)) + tickSize
+			return "", ""
+		}
+	}
+	return t.ReasonPostOnlyWouldCross, "Post-only order would take liquidity"
+}
@@ -2,16 +2,18 @@ package channels
 
 import (
 	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/databases/postgresql"
 	"PJS_Exchange/exchanges"
 	"PJS_Exchange/routes/ws"
 	t "PJS_Exchange/template"
 	"PJS_Exchange/utils"
 	"context"
-	"encoding/json"
 	"log"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/gofiber/websocket/v2"
 	"github.com/google/btree"
 	"github.com/google/uuid"
 )
@@ -20,25 +22,39 @@ var (
 	OP *ProcessOrders
 )
 
+// ProcessOrders 주문 요청을 받아 심볼별 매칭 엔진(SymbolShard)으로 분배
 type ProcessOrders struct {
 	OrderRequestChan chan t.OrderRequest
 	Running          bool
+
+	shards    map[string]*SymbolShard
+	lock      sync.RWMutex
+	startLock sync.Mutex // 심볼 시작, 정지, 삭제 직렬화 (호가 복구는 lock 밖에서 처리해서 다른 심볼의 주문을 막지 않음)
 }
 
 func NewProcessOrders() *ProcessOrders {
 	return &ProcessOrders{
 		OrderRequestChan: make(chan t.OrderRequest, 500),
 		Running:          false,
+		shards:           make(map[string]*SymbolShard),
 	}
 }
 
 func (po *ProcessOrders) Create() {
 	po.Running = true
 
+	// 실행 보고서 번호, 거래 중단 상태 복구 후 거래 가능한 심볼의 매칭 엔진 시작
+	loadExecSeqs()
+	po.restoreHalt()
+	po.startActiveSymbols()
+
 	for po.Running {
 		select {
-		case orderReq := <-po.OrderRequestChan:
-			po.processOrderRequest(orderReq)
+		case orderReq, ok := <-po.OrderRequestChan:
+			if !ok {
+				return
+			}
+			po.dispatch(orderReq)
 		}
 	}
 }
@@ -46,108 +62,291 @@ func (po *ProcessOrders) Create() {
 func (po *ProcessOrders) Destroy() {
 	po.Running = false
 	close(po.OrderRequestChan)
+
+	po.lock.Lock()
+	defer po.lock.Unlock()
+	for _, sh := range po.shards {
+		sh.stop()
+	}
 }
 
-// TODO 추후 protobuf로 변경
-func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
-	timestamp := time.Now().UnixMilli()
-	depth := ws.TempDepth[orderReq.Symbol]
-	depthOrderIDIndex := ws.TempDepthOrderIDIndex[orderReq.Symbol]
-	depthExecutionSeq := ws.TempDepthExecutionSeq[orderReq.Symbol]
-	bidAskOverLabCheck := ws.TempBidAskOverlapCheck[orderReq.Symbol]
+func (po *ProcessOrders) startActiveSymbols() {
+	ctx := context.Background()
+	symbols, err := postgresApp.Get().SymbolRepo().GetSymbols(ctx)
+	if err != nil {
+		log.Printf("Error fetching symbols for matching engines: %v", err)
+		return
+	}
+	if symbols == nil {
+		return
+	}
 
-	// depth | Asks, Bids, TotalAsks, TotalBids, BidTree, BottomAsk가 nil이면 초기화
-	if depth.Asks == nil {
-		depth.Asks = make(map[float64]map[string]t.Order)
+	// 단일가 매매 여부를 판단할 수 있도록 세션 상태를 먼저 확인
+	if exchanges.MarketStatus == "" {
+		_ = exchanges.UpdateMarketStatus()
 	}
-	if depth.Bids == nil {
-		depth.Bids = make(map[float64]map[string]t.Order)
+
+	for _, sym := range *symbols {
+		if sym.Status.Status == postgresql.StatusActive {
+			po.StartSymbol(sym.Symbol)
+		}
 	}
-	if depth.TotalAsks == nil {
-		depth.TotalAsks = make(map[float64]int)
+}
+
+// dispatch 주문을 해당 심볼의 매칭 엔진 큐로 전달
+func (po *ProcessOrders) dispatch(orderReq t.OrderRequest) {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	sh, ok := po.shards[orderReq.Symbol]
+	if !ok || !sh.Running() {
+		rejectOrderRequest(orderReq, 503, "Symbol '"+orderReq.Symbol+"' is not tradable")
+		return
 	}
-	if depth.TotalBids == nil {
-		depth.TotalBids = make(map[float64]int)
+	sh.enqueue(orderReq)
+}
+
+// StartSymbol 심볼의 매칭 엔진 시작 (거래 정지 후 재개시 기존 호가 유지)
+func (po *ProcessOrders) StartSymbol(symbol string) {
+	po.startLock.Lock()
+	defer po.startLock.Unlock()
+
+	po.lock.RLock()
+	sh, ok := po.shards[symbol]
+	po.lock.RUnlock()
+
+	if !ok {
+		// 설정 조회, 호가 복구는 시간이 걸리므로 lock 밖에서 처리하고 등록만 lock 안에서
+		sh = newRestoredShard(symbol)
+		po.lock.Lock()
+		po.shards[symbol] = sh
+		po.lock.Unlock()
+	} else {
+		sh.refreshParams()
 	}
-	if depth.BidTree == nil {
-		depth.BidTree = btree.New(4)
+	sh.start()
+
+	// 현재 세션의 단일가 매매 단계 반영 (다운된 동안 단계가 바뀌었으면 복구된 호가로 단일가 체결)
+	sh.runTask(func() {
+		sh.syncSession(exchanges.MarketStatus)
+	})
+}
+
+// newRestoredShard 심볼의 매칭 엔진 생성 (장중 다운되었다가 복구된 경우 저널로 호가 복구)
+func newRestoredShard(symbol string) *SymbolShard {
+	sh := NewSymbolShard(symbol)
+	sh.refreshParams() // 복구 중에도 같은 기준으로 체결되도록 먼저 설정을 읽어옴
+	sh.restoreTempLedger()
+	if ledger := ws.GetTempLedger(symbol); ledger != nil && ledger.Size() != 0 {
+		sh.Depth.LastPrice = ledger.GetMostRecent().Price
 	}
-	if depth.AskTree == nil {
-		depth.AskTree = btree.New(4)
+
+	journal, err := OpenOrderJournal(symbol)
+	if err != nil {
+		log.Printf("Error opening order journal for %s (orders will not be recoverable): %v", symbol, err)
+	} else {
+		sh.journal = journal
+		sh.RestoreExchange()
 	}
+	return sh
+}
 
-	// depthOrderIDIndex | nil이면 초기화
-	if depthOrderIDIndex == nil { // (OrderID: [userID, side, price, quantity])
-		depthOrderIDIndex = make(map[string][]interface{})
+// RefreshSymbolParams 관리자가 호가 단위, 최소 주문 수량을 변경한 경우 매칭 엔진에 반영
+func (po *ProcessOrders) RefreshSymbolParams(symbol string) {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	if sh, ok := po.shards[symbol]; ok {
+		sh.refreshParams()
 	}
+}
 
-	// depthExecutionSeq | nil이면 초기화
-	if depthExecutionSeq == nil { // (price: {"bids": [orderID1, orderID2], "asks": [orderID3, orderID4]}) FIFO
-		depthExecutionSeq = make(map[string]map[float64]*utils.Queue[string])
-		depthExecutionSeq["bids"] = make(map[float64]*utils.Queue[string])
-		depthExecutionSeq["asks"] = make(map[float64]*utils.Queue[string])
+// StopSymbol 심볼의 매칭 엔진 정지 (호가는 유지)
+func (po *ProcessOrders) StopSymbol(symbol string) {
+	po.startLock.Lock()
+	defer po.startLock.Unlock()
+	po.lock.Lock()
+	defer po.lock.Unlock()
+
+	if sh, ok := po.shards[symbol]; ok {
+		sh.stop()
 	}
+}
 
-	// bidAskOverLabCheck | nil이면 초기화
-	if bidAskOverLabCheck == nil { //
-		bidAskOverLabCheck = btree.New(4)
+// RemoveSymbol 심볼의 매칭 엔진 정지 및 호가 삭제 (상장 폐지)
+func (po *ProcessOrders) RemoveSymbol(symbol string) {
+	po.startLock.Lock()
+	defer po.startLock.Unlock()
+	po.lock.Lock()
+	defer po.lock.Unlock()
+
+	if sh, ok := po.shards[symbol]; ok {
+		sh.stop()
+		if sh.journal != nil {
+			if err := sh.journal.Remove(); err != nil {
+				log.Printf("Error removing order journal for %s: %v", symbol, err)
+			}
+		}
+		delete(po.shards, symbol)
 	}
+}
 
-	//입력 검증 | OrderID, OrderType, Price, Quantity
-	if orderReq.Status != t.StatusOpen && (orderReq.OrderID == "" || depthOrderIDIndex[orderReq.OrderID] == nil) { // OrderID는 빈값이거나 존재하지 않는 ID (수정, 취소시)
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "Invalid OrderID",
-			Code:      400,
+// ClearDepth 모든 심볼의 일일 호가 정리 (DAY 주문 만료, GTC, GTD 주문은 유지)
+func (po *ProcessOrders) ClearDepth() {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	for _, sh := range po.shards {
+		sh.runTask(sh.clearBook)
+
+		// 전일 종가가 바뀌었으므로 가격 제한폭 다시 계산
+		sh.refreshParams()
+	}
+}
+
+// GetOpenOrders 사용자의 미체결 주문 목록 (심볼의 매칭 엔진 안에서 읽음, 매칭 엔진이 없으면 nil)
+func (po *ProcessOrders) GetOpenOrders(symbol string, userID int) []t.OpenOrder {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	sh, ok := po.shards[symbol]
+	if !ok {
+		return nil
+	}
+	return sh.getOpenOrders(userID)
+}
+
+// GetAllOpenOrders 모든 심볼에서 사용자의 미체결 주문 목록
+func (po *ProcessOrders) GetAllOpenOrders(userID int) []t.OpenOrder {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	orders := make([]t.OpenOrder, 0)
+	for _, sh := range po.shards {
+		orders = append(orders, sh.getOpenOrders(userID)...)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt < orders[j].CreatedAt
+	})
+	return orders
+}
+
+// journalRequest 체결 처리 전에 저널에 기록 (장애 복구용), 처리가 끝나면 반환된 함수 호출
+func (sh *SymbolShard) journalRequest(orderReq t.OrderRequest) (func(), error) {
+	if sh.journal == nil || isReplaying(sh.Symbol) {
+		return func() {}, nil
+	}
+	seq, err := sh.journal.AppendOrder(orderReq)
+	if err != nil {
+		log.Printf("Error writing order journal for %s: %v", sh.Symbol, err)
+		return nil, err
+	}
+	return func() {
+		if err := sh.journal.AppendDone(seq); err != nil {
+			log.Printf("Error writing order journal for %s: %v", sh.Symbol, err)
 		}
+	}, nil
+}
+
+// TODO 추후 protobuf로 변경
+func (sh *SymbolShard) processOrderRequest(orderReq t.OrderRequest) {
+	timestamp := time.Now().UnixMilli()
+	depth := &sh.Depth
+	depthOrderIDIndex := sh.DepthOrderIDIndex
+	depthExecutionSeq := sh.DepthExecutionSeq
+	bidAskOverLabCheck := sh.BidAskOverlapCheck
+
+	// 요청 거절 (요청 결과 반환 + 거절 실행 보고서 전송)
+	reject := func(code int, reasonCode, message string) {
+		sendResult(orderReq.ResultChan, t.Result{
+			Timestamp:  timestamp,
+			Success:    false,
+			Message:    message,
+			Code:       code,
+			ReasonCode: reasonCode,
+		})
+		reportReject(depth, &orderReq, reasonCode, message)
+	}
+
+	// 발동 대기 중인 스탑 주문의 정정, 취소
+	if orderReq.Status != t.StatusOpen {
+		if stop, ok := sh.Stops.Orders[orderReq.OrderID]; ok {
+			sh.processStopRequest(orderReq, stop)
+			return
+		}
+	}
+
+	//입력 검증 | OrderID, OrderType, Price, StopPrice, Quantity, TimeInForce
+	if orderReq.Status != t.StatusOpen && (orderReq.OrderID == "" || depthOrderIDIndex[orderReq.OrderID] == nil) { // OrderID는 빈값이거나 존재하지 않는 ID (수정, 취소시)
+		reject(400, t.ReasonInvalidOrderID, "Invalid OrderID")
 		return
 	}
 	if orderReq.Status != t.StatusOpen && depthOrderIDIndex[orderReq.OrderID][0].(int) != orderReq.UserID { // UserID와 OrderID가 매칭되지 않는 경우 (수정, 취소시)
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "OrderID does not match UserID",
-			Code:      400,
-		}
+		reject(400, t.ReasonOrderNotOwned, "OrderID does not match UserID")
 		return
 	}
 	if (orderReq.Status == t.StatusOpen &&
-		(orderReq.OrderType != t.OrderTypeLimit && orderReq.OrderType != t.OrderTypeMarket)) ||
+		(orderReq.OrderType != t.OrderTypeLimit && orderReq.OrderType != t.OrderTypeMarket && !isStopOrder(orderReq.OrderType))) ||
 		(orderReq.Status != t.StatusOpen &&
 			orderReq.OrderType != "" &&
 			orderReq.OrderType != t.OrderTypeLimit &&
-			orderReq.OrderType != t.OrderTypeMarket) { // limit, market 가능, 수정, 취소는 추가로 "" 가능 (수정의 경우는 ""이면 기존 타입 유지)
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "Invalid OrderType",
-			Code:      400,
-		}
+			orderReq.OrderType != t.OrderTypeMarket) { // limit, market, stop, stop_limit 가능, 수정, 취소는 limit, market, "" 가능 (수정의 경우는 ""이면 기존 타입 유지)
+		reject(400, t.ReasonInvalidOrderType, "Invalid OrderType")
 		return
 	}
-	if orderReq.Price <= 0 && orderReq.OrderType != t.OrderTypeMarket && orderReq.Status != t.StatusCanceled { // 시장가 주문과 취소는 가격 무시
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "Invalid Price",
-			Code:      400,
-		}
+	if orderReq.Price <= 0 && orderReq.OrderType != t.OrderTypeMarket && orderReq.OrderType != t.OrderTypeStop && orderReq.Status != t.StatusCanceled { // 시장가 주문(스탑 포함)과 취소는 가격 무시
+		reject(400, t.ReasonInvalidPrice, "Invalid Price")
+		return
+	}
+	if orderReq.StopPrice <= 0 && isStopOrder(orderReq.OrderType) { // 스탑 주문은 발동 가격 필수
+		reject(400, t.ReasonInvalidStopPrice, "Invalid StopPrice")
 		return
 	}
 	if orderReq.Quantity <= 0 && orderReq.Status != t.StatusCanceled { // 취소는 수량 무시
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "Invalid Quantity",
-			Code:      400,
-		}
+		reject(400, t.ReasonInvalidQuantity, "Invalid Quantity")
 		return
 	}
+	if len(orderReq.Slippage) == 2 { // 슬리피지 기준 가격은 통화 단위이므로 최소 단위 가격으로 변환
+		orderReq.SlippagePrice = exchanges.ToPrice(orderReq.Slippage[0])
+	}
+	if orderReq.Status == t.StatusOpen { // 정정, 취소는 기존 유효 기간 유지
+		// 복구 중에는 이미 접수된 주문이므로 만료 시각은 검증하지 않음 (지난 주문은 복구 후 만료 처리)
+		if code, message := normalizeTimeInForce(&orderReq, timestamp, !isReplaying(sh.Symbol)); code != "" {
+			reject(400, code, message)
+			return
+		}
+		if code, message := normalizePostOnly(&orderReq); code != "" {
+			reject(400, code, message)
+			return
+		}
+		if code, message := normalizeDisplayQuantity(&orderReq); code != "" {
+			reject(400, code, message)
+			return
+		}
+		if code, message := normalizeSelfTradePrevention(&orderReq); code != "" {
+			reject(400, code, message)
+			return
+		}
+	} else {
+		orderReq.TimeInForce = ""
+		orderReq.ExpireAt = 0
+		orderReq.PostOnly = ""
+		orderReq.DisplayQuantity = 0
+		orderReq.SelfTradePrevention = ""
+		orderReq.ClientOrderID = "" // 정정, 취소는 order_id 로 처리 (client_order_id 는 기존 주문 값 유지)
+	}
+
+	// 호가 단위, 최소 주문 수량 검증 (복구 중에는 이미 접수된 주문이므로 생략)
+	if !isReplaying(sh.Symbol) {
+		if code, message := sh.params.validate(&orderReq); code != "" {
+			reject(400, code, message)
+			return
+		}
+	}
 
 	// 주문 수정시 OrderType이 빈값이면 기존 타입 유지
 	if (orderReq.Status == t.StatusModified || orderReq.Status == t.StatusCanceled) && orderReq.OrderType == "" {
-		previousPrice := depthOrderIDIndex[orderReq.OrderID][2].(float64)
+		previousPrice := depthOrderIDIndex[orderReq.OrderID][2].(t.Price)
 		if previousPrice == 0 {
 			orderReq.OrderType = t.OrderTypeMarket
 		} else {
@@ -155,45 +354,101 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 		}
 	}
 
-	// 시장가 주문은 가격을 0으로 설정
-	if orderReq.OrderType == t.OrderTypeMarket {
+	// Post-Only 주문은 지정가로 정정하는 동안 Post-Only 유지
+	if orderReq.Status == t.StatusModified && orderReq.OrderType == t.OrderTypeLimit {
+		if exec, ok := depth.Executions[orderReq.OrderID]; ok {
+			orderReq.PostOnly = exec.PostOnly
+		}
+	}
+
+	// 정정한 주문이 바로 체결되는 경우에도 접수 시 자기매매 방지 모드 유지
+	if orderReq.Status == t.StatusModified {
+		if exec, ok := depth.Executions[orderReq.OrderID]; ok {
+			orderReq.SelfTradePrevention = exec.SelfTradePrevention
+		}
+	}
+
+	// 시장가 주문은 가격을 0으로 설정, 스탑 주문이 아니면 발동 가격 무시
+	if orderReq.OrderType == t.OrderTypeMarket || orderReq.OrderType == t.OrderTypeStop {
 		orderReq.Price = 0
 	}
+	if !isStopOrder(orderReq.OrderType) {
+		orderReq.StopPrice = 0
+	}
 
 	// 취소 주문은 이전 가격과 수량 유지
 	if orderReq.Status == t.StatusCanceled {
-		orderReq.Price = depthOrderIDIndex[orderReq.OrderID][2].(float64)
+		orderReq.Price = depthOrderIDIndex[orderReq.OrderID][2].(t.Price)
 		orderReq.Quantity = depthOrderIDIndex[orderReq.OrderID][3].(int)
 	}
 
 	// 주문을 변경할때 가격, 수량 모두 변화가 없으면 무시
 	if orderReq.Status == t.StatusModified &&
-		orderReq.Price == depthOrderIDIndex[orderReq.OrderID][2].(float64) &&
-		orderReq.Quantity == depthOrderIDIndex[orderReq.OrderID][3].(int) {
-		orderReq.ResultChan <- t.Result{
-			Timestamp: timestamp,
-			Success:   false,
-			Message:   "No changes in Price or Quantity",
-			Code:      400,
+		orderReq.Price == depthOrderIDIndex[orderReq.OrderID][2].(t.Price) &&
+		orderReq.Quantity == leavesQuantity(orderReq.OrderID, depth, &depthOrderIDIndex) {
+		reject(400, t.ReasonNoChanges, "No changes in Price or Quantity")
+		return
+	}
+
+	// 거래 중단 중에는 취소만 가능 (접수 차단 전에 대기열에 들어온 주문)
+	if sh.auction == t.AuctionHalt && orderReq.Status != t.StatusCanceled {
+		reject(503, t.ReasonMarketHalted, "Trading is halted")
+		return
+	}
+
+	// 단일가 매매 중에는 바로 체결되어야 하는 주문(시장가, IOC, FOK) 불가 (스탑 주문은 발동 전까지 대기)
+	if sh.auction != "" && orderReq.Status != t.StatusCanceled && !isStopOrder(orderReq.OrderType) &&
+		(orderReq.OrderType == t.OrderTypeMarket || orderReq.TimeInForce == t.TimeInForceIOC || orderReq.TimeInForce == t.TimeInForceFOK) {
+		reject(400, t.ReasonAuctionInProgress, "Market, IOC and FOK orders are not accepted during the call auction")
+		return
+	}
+
+	// Post-Only 주문이 바로 체결되는 경우 거절 또는 가격 조정 (조정된 가격으로 저널에 기록, 단일가 매매 중에는 바로 체결되지 않으므로 생략)
+	if orderReq.PostOnly != "" && orderReq.Status != t.StatusCanceled && sh.auction == "" {
+		if code, message := sh.applyPostOnly(&orderReq); code != "" {
+			reject(400, code, message)
+			return
 		}
+	}
+
+	// 접수 시각 기록 (클라이언트가 보낸 값은 무시, 복구 시에는 저널에 기록된 최초 접수 시각 유지)
+	if orderReq.Timestamp == 0 || !isReplaying(sh.Symbol) {
+		orderReq.Timestamp = timestamp
+	}
+
+	// 체결 처리 전에 저널에 기록 (장애 복구용)
+	done, err := sh.journalRequest(orderReq)
+	if err != nil {
+		reject(500, t.ReasonInternalError, "Failed to record order")
 		return
 	}
+	defer done()
 
-	orderReq.ResultChan <- t.Result{
+	sendResult(orderReq.ResultChan, t.Result{
 		Timestamp: timestamp,
 		Success:   true,
 		Message:   "Order processed successfully",
 		Code:      200,
-	}
+	})
 
 	// 주문 등록
 	switch orderReq.Status {
 	case t.StatusOpen:
-		// 신규 주문 처리 로직
-		processOpen(&orderReq, &depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
+		// 신규 주문 처리 로직 (스탑 주문은 발동 전까지 호가에 올리지 않음)
+		if isStopOrder(orderReq.OrderType) {
+			stop := orderReq
+			stop.ResultChan = nil
+			sh.Stops.add(&StopOrder{
+				Order:     stop,
+				CreatedAt: orderReq.Timestamp,
+				UpdatedAt: orderReq.Timestamp,
+			})
+		} else {
+			processOpen(visibleOrder(&orderReq, depth), depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
+		}
 	case t.StatusModified:
 		// 주문 수정 처리 로직
-		if orderReq.Price != depthOrderIDIndex[orderReq.OrderID][2].(float64) { // 가격이 변경되었을때만 브로드캐스트
+		if orderReq.Price != depthOrderIDIndex[orderReq.OrderID][2].(t.Price) { // 가격이 변경되었을때만 브로드캐스트
 			timestamp = time.Now().UnixMilli()
 
 			switch orderReq.Side {
@@ -203,8 +458,8 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 					Timestamp: timestamp,
 					Symbol:    orderReq.Symbol,
 					Side:      t.Bids,
-					Price:     depthOrderIDIndex[orderReq.OrderID][2].(float64),
-					Quantity:  depth.TotalBids[depthOrderIDIndex[orderReq.OrderID][2].(float64)] - depthOrderIDIndex[orderReq.OrderID][3].(int),
+					Price:     depthOrderIDIndex[orderReq.OrderID][2].(t.Price),
+					Quantity:  depth.TotalBids[depthOrderIDIndex[orderReq.OrderID][2].(t.Price)] - depthOrderIDIndex[orderReq.OrderID][3].(int),
 				})
 			case t.SideSell:
 				orderReq.Timestamp = timestamp
@@ -212,16 +467,16 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 					Timestamp: timestamp,
 					Symbol:    orderReq.Symbol,
 					Side:      t.Asks,
-					Price:     depthOrderIDIndex[orderReq.OrderID][2].(float64),
-					Quantity:  depth.TotalAsks[depthOrderIDIndex[orderReq.OrderID][2].(float64)] - depthOrderIDIndex[orderReq.OrderID][3].(int),
+					Price:     depthOrderIDIndex[orderReq.OrderID][2].(t.Price),
+					Quantity:  depth.TotalAsks[depthOrderIDIndex[orderReq.OrderID][2].(t.Price)] - depthOrderIDIndex[orderReq.OrderID][3].(int),
 				})
 			}
 		}
 
-		processModify(&orderReq, &depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
+		processModify(visibleOrder(&orderReq, depth), depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
 	case t.StatusCanceled:
 		// 주문 취소 처리 로직
-		processCancel(&orderReq, &depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
+		processCancel(&orderReq, depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
 	}
 
 	switch orderReq.Side {
@@ -238,7 +493,7 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 				Quantity:  depth.TotalBids[orderReq.Price],
 			})
 		}
-		notifyUser(orderReq)
+		reportAccepted(depth, &orderReq)
 	case t.SideSell:
 		//log.Printf("Processing Sell Order: %+v", orderReq)
 		timestamp = time.Now().UnixMilli()
@@ -252,17 +507,23 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 				Quantity:  depth.TotalAsks[orderReq.Price],
 			})
 		}
-		notifyUser(orderReq)
+		reportAccepted(depth, &orderReq)
+	}
+
+	// 단일가 매매 중에는 체결하지 않고 예상 체결가만 갱신
+	if sh.auction != "" {
+		sh.broadcastIndicative()
+		return
 	}
 
-	// 주문 체결
-	processOrder(&orderReq, &depth, &depthOrderIDIndex, bidAskOverLabCheck, &depthExecutionSeq)
+	// 주문 체결 (체결 가격이 직전 체결가 대비 범위를 벗어나면 체결 중단 후 변동성 완화장치 발동)
+	if !isStopOrder(orderReq.OrderType) {
+		sh.matchOrder(&orderReq)
+	}
+
+	// 최종 체결가가 바뀌었으면 스탑 주문 발동
+	sh.triggerStops()
 
-	// 변경된 값 다시 저장
-	ws.TempDepth[orderReq.Symbol] = depth
-	ws.TempDepthOrderIDIndex[orderReq.Symbol] = depthOrderIDIndex
-	ws.TempDepthExecutionSeq[orderReq.Symbol] = depthExecutionSeq
-	ws.TempBidAskOverlapCheck[orderReq.Symbol] = bidAskOverLabCheck
 	//log.Println("---- Order Processed ----")
 	//log.Println("Updated Depth:", depth)
 	//log.Println("Updated DepthOrderIDIndex:", depthOrderIDIndex)
@@ -274,12 +535,18 @@ func (po *ProcessOrders) processOrderRequest(orderReq t.OrderRequest) {
 	return
 }
 
-func processOpen(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+func processOpen(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
 	//log.Printf("processOpen called with Order: %+v", orderReq)
 	price := orderReq.Price
+	createdAt := orderReq.Timestamp
+	if createdAt == 0 {
+		createdAt = time.Now().UnixMilli()
+	}
 	order := t.Order{
-		UserID:   orderReq.UserID,
-		Quantity: orderReq.Quantity,
+		UserID:    orderReq.UserID,
+		Quantity:  orderReq.Quantity,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
 	}
 	side := ""
 
@@ -297,11 +564,11 @@ func processOpen(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map
 		depth.TotalBids[price] += orderReq.Quantity
 
 		// 매수 호가 트리에 추가
-		depth.BidTree.ReplaceOrInsert(t.Float64Item(price))
+		depth.BidTree.ReplaceOrInsert(t.PriceItem(price))
 
 		// 매수 호가와 매도 호가가 겹치는 경우 체크
 		if _, exists := depth.Asks[price]; exists {
-			(*bidAskOverLab).ReplaceOrInsert(t.Float64Item(price))
+			(*bidAskOverLab).ReplaceOrInsert(t.PriceItem(price))
 		}
 
 		// 가격대별 주문 순서에 추가
@@ -321,11 +588,11 @@ func processOpen(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map
 		depth.TotalAsks[price] += orderReq.Quantity
 
 		// 매도 호가 트리에 추가
-		depth.AskTree.ReplaceOrInsert(t.Float64Item(price))
+		depth.AskTree.ReplaceOrInsert(t.PriceItem(price))
 
 		// 매수 호가와 매도 호가가 겹치는 경우 체크
 		if _, exists := depth.Bids[price]; exists {
-			(*bidAskOverLab).ReplaceOrInsert(t.Float64Item(price))
+			(*bidAskOverLab).ReplaceOrInsert(t.PriceItem(price))
 		}
 
 		// 가격대별 주문 순서에 추가
@@ -344,30 +611,32 @@ func processOpen(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map
 	//log.Printf("Order %s added to depth at price %.2f with quantity %depth", orderReq.OrderID, price, orderReq.Quantity)
 }
 
-func processModify(orderRequest *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+func processModify(orderRequest *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
 	//log.Printf("processModify called with Order: %+v", orderRequest)
 	previousQuantity := (*depthIndex)[orderRequest.OrderID][3].(int)
 
-	if orderRequest.Quantity < previousQuantity && orderRequest.Price == (*depthIndex)[orderRequest.OrderID][2].(float64) {
-		// 가격을 유지하고 수량을 줄이는 경우는 우선순위 유지
-		price := (*depthIndex)[orderRequest.OrderID][2].(float64)
+	if orderRequest.Quantity <= previousQuantity && orderRequest.Price == (*depthIndex)[orderRequest.OrderID][2].(t.Price) {
+		// 가격을 유지하고 수량을 줄이는 경우는 우선순위 유지 (아이스버그 주문은 노출 수량이 그대로인 경우 포함)
+		price := (*depthIndex)[orderRequest.OrderID][2].(t.Price)
 		switch orderRequest.Side {
 		case t.SideBuy:
 			if _, ok := depth.Bids[price]; ok {
-				depth.Bids[price][orderRequest.OrderID] = t.Order{
-					UserID:   orderRequest.UserID,
-					Quantity: orderRequest.Quantity,
-				}
+				order := depth.Bids[price][orderRequest.OrderID]
+				order.UserID = orderRequest.UserID
+				order.Quantity = orderRequest.Quantity
+				order.UpdatedAt = time.Now().UnixMilli()
+				depth.Bids[price][orderRequest.OrderID] = order
 
 				depth.TotalBids[price] -= previousQuantity
 				depth.TotalBids[price] += orderRequest.Quantity
 			}
 		case t.SideSell:
 			if _, ok := depth.Asks[price]; ok {
-				depth.Asks[price][orderRequest.OrderID] = t.Order{
-					UserID:   orderRequest.UserID,
-					Quantity: orderRequest.Quantity,
-				}
+				order := depth.Asks[price][orderRequest.OrderID]
+				order.UserID = orderRequest.UserID
+				order.Quantity = orderRequest.Quantity
+				order.UpdatedAt = time.Now().UnixMilli()
+				depth.Asks[price][orderRequest.OrderID] = order
 
 				depth.TotalAsks[price] -= previousQuantity
 				depth.TotalAsks[price] += orderRequest.Quantity
@@ -378,20 +647,74 @@ func processModify(orderRequest *t.OrderRequest, depth *t.MarketDepth, depthInde
 		(*depthIndex)[orderRequest.OrderID][3] = orderRequest.Quantity
 	} else {
 		// 단일 주문에서 수량을 늘리거나 가격(+시장가, 지정가 변경)을 변경하는 경우는 우선순위 재조정
+		previous, _ := lookupOrder(orderRequest.OrderID, depth, depthIndex)
 		processCancel(orderRequest, depth, depthIndex, bidAskOverLab, executionSeq)
 		processOpen(orderRequest, depth, depthIndex, bidAskOverLab, executionSeq)
+		keepOrderInfo(orderRequest.OrderID, previous, depth, depthIndex)
 		return
 	}
 	//log.Printf("Order %s modified in depth to quantity %depth", orderRequest.OrderID, orderRequest.Quantity)
 }
 
-func processCancel(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+// lookupOrder 주문 ID로 호가에 남아있는 주문 조회
+func lookupOrder(orderID string, depth *t.MarketDepth, depthIndex *map[string][]interface{}) (t.Order, bool) {
+	index := (*depthIndex)[orderID]
+	if index == nil {
+		return t.Order{}, false
+	}
+
+	price := index[2].(t.Price)
+	switch index[1].(string) {
+	case t.Bids:
+		order, ok := depth.Bids[price][orderID]
+		return order, ok
+	case t.Asks:
+		order, ok := depth.Asks[price][orderID]
+		return order, ok
+	}
+	return t.Order{}, false
+}
+
+// storeOrder 호가에 남아있는 주문 정보 갱신 (수량 변경은 processModify 사용)
+func storeOrder(orderID string, order t.Order, depth *t.MarketDepth, depthIndex *map[string][]interface{}) {
+	index := (*depthIndex)[orderID]
+	if index == nil {
+		return
+	}
+
+	price := index[2].(t.Price)
+	switch index[1].(string) {
+	case t.Bids:
+		if _, ok := depth.Bids[price][orderID]; ok {
+			depth.Bids[price][orderID] = order
+		}
+	case t.Asks:
+		if _, ok := depth.Asks[price][orderID]; ok {
+			depth.Asks[price][orderID] = order
+		}
+	}
+}
+
+// keepOrderInfo 호가에 다시 넣은 주문의 최초 접수 시각을 이전 주문 정보로 유지
+func keepOrderInfo(orderID string, previous t.Order, depth *t.MarketDepth, depthIndex *map[string][]interface{}) {
+	if previous.CreatedAt == 0 {
+		return
+	}
+	order, ok := lookupOrder(orderID, depth, depthIndex)
+	if !ok {
+		return
+	}
+	order.CreatedAt = previous.CreatedAt
+	storeOrder(orderID, order, depth, depthIndex)
+}
+
+func processCancel(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
 	//log.Printf("processCancel called with Order: %+v", orderReq)
 	if (*depthIndex)[orderReq.OrderID] == nil {
 		// 이미 취소된 주문이거나 존재하지 않는 주문
 		return
 	}
-	price := (*depthIndex)[orderReq.OrderID][2].(float64)
+	price := (*depthIndex)[orderReq.OrderID][2].(t.Price)
 
 	// depth에서 삭제
 	switch orderReq.Side {
@@ -413,10 +736,10 @@ func processCancel(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			// 내 호가에 나만 남아있는 경우 체크
 			if _, exists := depth.Bids[price]; !exists {
 				// BidTree에서 삭제
-				depth.BidTree.Delete(t.Float64Item(price))
+				depth.BidTree.Delete(t.PriceItem(price))
 
 				// 매수 호가와 매도 호가가 더이상 겹치지 않음
-				bidAskOverLab.Delete(t.Float64Item(price))
+				bidAskOverLab.Delete(t.PriceItem(price))
 			}
 
 			// 가격대별 주문 순서에서 삭제
@@ -442,10 +765,10 @@ func processCancel(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			// 내 호가에 나만 남아있는 경우 체크
 			if _, exists := depth.Asks[price]; !exists {
 				// AskTree에서 삭제
-				depth.AskTree.Delete(t.Float64Item(price))
+				depth.AskTree.Delete(t.PriceItem(price))
 
 				// 매수 호가와 매도 호가가 더이상 겹치지 않음
-				bidAskOverLab.Delete(t.Float64Item(price))
+				bidAskOverLab.Delete(t.PriceItem(price))
 			}
 
 			// 가격대별 주문 순서에서 삭제
@@ -460,7 +783,8 @@ func processCancel(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 	//log.Printf("Order %s canceled and removed from depth", orderReq.OrderID)
 }
 
-func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+// processOrder 주문 체결 (referencePrice: 당일 체결이 없을 때 현재가로 사용할 기준가)
+func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], referencePrice t.Price) {
 	//log.Printf("processOrder called with Order: %+v", orderReq)
 
 	// 테스트용 코드
@@ -480,22 +804,13 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 	// 테스트용 코드
 
 	// 현재가를 가져와야함 현재가는 가장 최근에 체결된 가격 -> 전일 종가 -> 공모가 순으로 가져옴
-	var currentPrice float64
-	if ws.TempLedger[orderReq.Symbol] != nil && ws.TempLedger[orderReq.Symbol].Size() != 0 {
+	var currentPrice t.Price
+	if ledger := ws.GetTempLedger(orderReq.Symbol); ledger != nil && ledger.Size() != 0 {
 		// 가장 최근 체결 가격(상장 직후라면 상장가)
-		currentPrice = ws.TempLedger[orderReq.Symbol].GetMostRecent().Price
+		currentPrice = ledger.GetMostRecent().Price
 	} else {
-		// TODO 전일 종가로 설정
-
-		// 전일 종가도 없으면 공모가로 설정 (단순 Fallback 용 아마 여기까지 올일은 없을듯)
-		ctx := context.Background()
-		ipoPrice, err := postgresApp.Get().SymbolRepo().GetIPOPrice(ctx, orderReq.Symbol)
-		if err != nil {
-			log.Printf("Error fetching IPO price for %s: %v", orderReq.Symbol, err)
-			return
-		} else {
-			currentPrice = ipoPrice
-		}
+		// 기준가 (전일 종가, 종가가 없으면 공모가, 심볼 설정을 읽어올 때 한 번만 조회)
+		currentPrice = referencePrice
 	}
 	//log.Printf("Current Price for %s: %.2f", orderReq.Symbol, currentPrice)
 
@@ -522,16 +837,16 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 						Quantity:  depth.TotalBids[orderReq.Price],
 					})
 				}
-				notifyUser(*orderReq)
+				reportCancel(depth, orderReq, "No liquidity available")
 				return
 			}
 			if orderReq.MarketOrderType == t.MarketOrderFOK {
 				// FOK인 경우 체결 가능한 물량이 내 물량보다 적으면 주문 취소
 				//log.Printf("FOK Market Buy Order: Checking available volume")
 				totalAvailable := 0
-				depth.AskTree.AscendGreaterOrEqual(t.Float64Item(0), func(i btree.Item) bool {
-					price := float64(i.(t.Float64Item))
-					totalAvailable += depth.TotalAsks[price]
+				depth.AskTree.AscendGreaterOrEqual(t.PriceItem(0), func(i btree.Item) bool {
+					price := t.Price(i.(t.PriceItem))
+					totalAvailable += depth.TotalAsks[price] - selfTradeQuantity(orderReq, depth.Asks[price])
 					if totalAvailable >= orderReq.Quantity {
 						return false // 충분한 물량 확보, 반복 종료
 					}
@@ -553,17 +868,15 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 							Quantity:  depth.TotalBids[orderReq.Price],
 						})
 					}
-					notifyUser(*orderReq)
+					reportCancel(depth, orderReq, "Insufficient liquidity for FOK order")
 					return
 				}
 			}
 			if orderReq.Slippage != nil && len(orderReq.Slippage) == 2 {
 				// 슬리피지 설정이 있는 경우
-				//log.Printf("Market Buy Order with Slippage: Checking slippage limits")
-				maxSlippagePrice := orderReq.Slippage[0] * (1 + orderReq.Slippage[1]/100)
+				maxSlippagePrice := t.Price(math.Floor(float64(orderReq.SlippagePrice) * (1 + orderReq.Slippage[1]/100)))
 				if currentPrice > maxSlippagePrice {
 					// 최대 슬리피지 가격보다 높으면 주문 취소
-					//log.Printf("Lowest ask price %.2f exceeds max slippage price %.2f. Cancelling order.", currentPrice, maxSlippagePrice)
 					processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 
 					timestamp := time.Now().UnixMilli()
@@ -578,7 +891,7 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 							Quantity:  depth.TotalBids[orderReq.Price],
 						})
 					}
-					notifyUser(*orderReq)
+					reportCancel(depth, orderReq, "Price exceeds slippage limit")
 					return
 				}
 			}
@@ -602,16 +915,16 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 						Quantity:  depth.TotalAsks[orderReq.Price],
 					})
 				}
-				notifyUser(*orderReq)
+				reportCancel(depth, orderReq, "No liquidity available")
 				return
 			}
 			if orderReq.MarketOrderType == t.MarketOrderFOK {
 				// FOK인 경우 체결 가능한 물량이 내 물량보다 적으면 주문 취소
 				//log.Printf("FOK Market Sell Order: Checking available volume")
 				totalAvailable := 0
-				depth.BidTree.DescendLessOrEqual(t.Float64Item(1e10), func(i btree.Item) bool {
-					price := float64(i.(t.Float64Item))
-					totalAvailable += depth.TotalBids[price]
+				depth.BidTree.DescendLessOrEqual(t.PriceItem(math.MaxInt64), func(i btree.Item) bool {
+					price := t.Price(i.(t.PriceItem))
+					totalAvailable += depth.TotalBids[price] - selfTradeQuantity(orderReq, depth.Bids[price])
 					if totalAvailable >= orderReq.Quantity {
 						return false // 충분한 물량 확보, 반복 종료
 					}
@@ -634,17 +947,15 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 							Quantity:  depth.TotalAsks[orderReq.Price],
 						})
 					}
-					notifyUser(*orderReq)
+					reportCancel(depth, orderReq, "Insufficient liquidity for FOK order")
 					return
 				}
 			}
 			if orderReq.Slippage != nil && len(orderReq.Slippage) == 2 {
 				// 슬리피지 설정이 있는 경우
-				//log.Printf("Market Sell Order with Slippage: Checking slippage limits")
-				minSlippagePrice := orderReq.Slippage[0] * (1 - orderReq.Slippage[1]/100)
+				minSlippagePrice := t.Price(math.Ceil(float64(orderReq.SlippagePrice) * (1 - orderReq.Slippage[1]/100)))
 				if currentPrice < minSlippagePrice {
 					// 최소 슬리피지 가격보다 낮으면 주문 취소
-					//log.Printf("Highest bid price %.2f is below min slippage price %.2f. Cancelling order.", currentPrice, minSlippagePrice)
 					processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 
 					timestamp := time.Now().UnixMilli()
@@ -659,7 +970,7 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 							Quantity:  depth.TotalAsks[orderReq.Price],
 						})
 					}
-					notifyUser(*orderReq)
+					reportCancel(depth, orderReq, "Price exceeds slippage limit")
 					return
 				}
 			}
@@ -670,29 +981,43 @@ func processOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *ma
 
 	// 지정가 주문 처리
 	if orderReq.OrderType == t.OrderTypeLimit {
+		if orderReq.TimeInForce == t.TimeInForceFOK && limitAvailableQuantity(orderReq, depth) < orderReq.Quantity {
+			// FOK인 경우 지정가 이내에서 체결 가능한 물량이 내 물량보다 적으면 주문 취소
+			cancelUnfilled(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, "Insufficient liquidity for FOK order")
+			return
+		}
+
+		crossed := true
 		switch orderReq.Side {
 		case t.SideBuy:
 			// 매수 지정가 주문 처리
-			if (*bidAskOverLab).Get(t.Float64Item(orderReq.Price)) == nil && (depth.AskTree.Min() == nil || depth.AskTree.Min().(t.Float64Item) > t.Float64Item(orderReq.Price)) {
+			if (*bidAskOverLab).Get(t.PriceItem(orderReq.Price)) == nil && (depth.AskTree.Min() == nil || depth.AskTree.Min().(t.PriceItem) > t.PriceItem(orderReq.Price)) {
 				// 매수 호가와 매도 호가가 겹치지 않으면 체결 불가
 				//log.Println("No overlapping bids and asks, skipping order matching")
-				return
+				crossed = false
 			}
 		case t.SideSell:
 			// 매도 지정가 주문 처리
-			if (*bidAskOverLab).Get(t.Float64Item(orderReq.Price)) == nil && (depth.BidTree.Max() == nil || depth.BidTree.Max().(t.Float64Item) < t.Float64Item(orderReq.Price)) {
+			if (*bidAskOverLab).Get(t.PriceItem(orderReq.Price)) == nil && (depth.BidTree.Max() == nil || depth.BidTree.Max().(t.PriceItem) < t.PriceItem(orderReq.Price)) {
 				// 매수 호가와 매도 호가가 겹치지 않으면 체결 불가
 				//log.Println("No overlapping bids and asks, skipping order matching")
-				return
+				crossed = false
 			}
 		}
 
-		processLimitOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+		if crossed {
+			processLimitOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+		}
+
+		// IOC, FOK 주문은 즉시 체결되지 않은 수량을 호가에 남기지 않음
+		if orderReq.TimeInForce == t.TimeInForceIOC || orderReq.TimeInForce == t.TimeInForceFOK {
+			cancelUnfilled(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, "Remaining quantity canceled")
+		}
 	}
 	//log.Printf("Order processing completed")
 }
 
-func processMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+func processMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
 	/*
 		반대 진영 물량이 내 물량보다 적으면 전부 소진 후 남은 물량은 취소 처리
 	*/
@@ -704,19 +1029,19 @@ func processMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthInd
 	case t.SideBuy:
 		// 매수 지정가 주문 처리
 		depthAskTreeClone := depth.AskTree.Clone()
-		depthAskTreeClone.Ascend(func(i btree.Item) bool {
-			buyMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.Float64Item), &remainingQuantity)
+		depthAskTreeClone.Ascend(bandLimited(depth, func(i btree.Item) bool {
+			buyMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.PriceItem), &remainingQuantity)
 
 			return remainingQuantity > 0 // 남은 수량이 0이 될 때까지 계속 반복
-		})
+		}))
 	case t.SideSell:
 		// 매도 지정가 주문 처리
 		depthBidTreeClone := depth.BidTree.Clone()
-		depthBidTreeClone.Descend(func(i btree.Item) bool {
-			sellMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.Float64Item), &remainingQuantity)
+		depthBidTreeClone.Descend(bandLimited(depth, func(i btree.Item) bool {
+			sellMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.PriceItem), &remainingQuantity)
 
 			return remainingQuantity > 0 // 남은 수량이 0이 될 때까지 계속 반복
-		})
+		}))
 	}
 
 	if remainingQuantity >= 0 {
@@ -726,13 +1051,15 @@ func processMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthInd
 		orderReq.Timestamp = timestamp
 		orderReq.Status = t.StatusCanceled
 		orderReq.Quantity = remainingQuantity
-		notifyUser(*orderReq)
+		if remainingQuantity > 0 {
+			reportCancel(depth, orderReq, "Remaining quantity canceled")
+		}
 		return
 	}
 	//log.Printf("Market order processing completed")
 }
 
-func processLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string]) {
+func processLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string]) {
 	/*
 		체결 우선순위
 		1.가격
@@ -749,24 +1076,25 @@ func processLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthInde
 	case t.SideBuy:
 		// 매수 지정가 주문 처리
 		lowAsk := depth.AskTree.Min()
-		if lowAsk != nil && float64(lowAsk.(t.Float64Item)) <= orderReq.Price {
+		if lowAsk != nil && t.Price(lowAsk.(t.PriceItem)) <= orderReq.Price {
 			// 매도 호가가 존재하고 최우선 매도 호가가 내 지정가 이하인 경우 체결 시도
 			depthAskTreeClone := depth.AskTree.Clone()
-			depthAskTreeClone.AscendLessThan(t.Float64Item(orderReq.Price), func(i btree.Item) bool {
-				buyMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.Float64Item), &remainingQuantity)
+			depthAskTreeClone.AscendLessThan(t.PriceItem(orderReq.Price), bandLimited(depth, func(i btree.Item) bool {
+				buyMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.PriceItem), &remainingQuantity)
 
 				return remainingQuantity > 0 // 남은 수량이 0이 될 때까지 계속 반복
-			})
+			}))
 		}
 
-		if remainingQuantity > 0 {
+		// 체결 가능 범위를 벗어나면 남은 수량은 체결하지 않고 호가에 남김 (변동성 완화장치)
+		if remainingQuantity > 0 && !depth.Interrupted && (len(depth.Asks[orderReq.Price]) == 0 || !outsideBand(depth, orderReq.Price)) {
 			buyLimitOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, &remainingQuantity)
 		}
 
 		if remainingQuantity > 0 {
-			// 남은 수량이 있으면 호가 업데이트
+			// 남은 수량이 있으면 호가 업데이트 (아이스버그 주문은 노출 수량만)
 			orderReq.Quantity = remainingQuantity
-			processModify(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+			processModify(visibleOrder(orderReq, depth), depth, depthIndex, bidAskOverLab, executionSeq)
 		}
 		// 매수자 호가 업데이트
 		broadcastDepth(t.UpdateDepth{
@@ -779,24 +1107,25 @@ func processLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthInde
 	case t.SideSell:
 		// 매도 지정가 주문 처리
 		highBid := depth.BidTree.Max()
-		if highBid != nil && float64(highBid.(t.Float64Item)) >= orderReq.Price {
+		if highBid != nil && t.Price(highBid.(t.PriceItem)) >= orderReq.Price {
 			// 매수 호가가 존재하고 최우선 매수 호가가 내 지정가 이상인 경우 체결 시도
 			depthBidTreeClone := depth.BidTree.Clone()
-			depthBidTreeClone.DescendGreaterThan(t.Float64Item(orderReq.Price), func(i btree.Item) bool {
-				sellMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.Float64Item), &remainingQuantity)
+			depthBidTreeClone.DescendGreaterThan(t.PriceItem(orderReq.Price), bandLimited(depth, func(i btree.Item) bool {
+				sellMarketOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, i.(t.PriceItem), &remainingQuantity)
 
 				return remainingQuantity > 0 // 남은 수량이 0이 될 때까지 계속 반복
-			})
+			}))
 		}
 
-		if remainingQuantity > 0 {
+		// 체결 가능 범위를 벗어나면 남은 수량은 체결하지 않고 호가에 남김 (변동성 완화장치)
+		if remainingQuantity > 0 && !depth.Interrupted && (len(depth.Bids[orderReq.Price]) == 0 || !outsideBand(depth, orderReq.Price)) {
 			sellLimitOrder(orderReq, depth, depthIndex, bidAskOverLab, executionSeq, &remainingQuantity)
 		}
 
 		if remainingQuantity > 0 {
-			// 남은 수량이 있으면 호가 업데이트
+			// 남은 수량이 있으면 호가 업데이트 (아이스버그 주문은 노출 수량만)
 			orderReq.Quantity = remainingQuantity
-			processModify(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+			processModify(visibleOrder(orderReq, depth), depth, depthIndex, bidAskOverLab, executionSeq)
 		}
 		// 매도자 호가 업데이트
 		broadcastDepth(t.UpdateDepth{
@@ -812,13 +1141,14 @@ func processLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthInde
 	//log.Printf("Limit order processing completed")
 }
 
-func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string], i btree.Item, remainingQuantity *int) {
+func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], i btree.Item, remainingQuantity *int) {
 	// 가장 낮은 매도 호가부터 시작
-	price := float64(i.(t.Float64Item)) // 매도 호가중 가장 낮은 가격 가져오기
-	askOrders := depth.Asks[price]      // 해당 가격대의 모든 매도 주문
+	price := t.Price(i.(t.PriceItem)) // 매도 호가중 가장 낮은 가격 가져오기
 	for sequence := (*executionSeq)[t.Asks][price]; sequence != nil && !sequence.IsEmpty() && *remainingQuantity > 0; {
 		executedQuantity := 0 // 체결된 수량
 		timestamp := time.Now().UnixMilli()
+		executionID := uuid.NewString() // 체결 알림과 원장에 같은 체결 ID 사용
+		askOrders := depth.Asks[price]  // 해당 가격대의 모든 매도 주문 (아이스버그 주문이 다시 채워지면 새로 생성될 수 있음)
 		if len(askOrders) == 0 {
 			// 혹시 모를 무한루프 방지
 			break
@@ -829,6 +1159,12 @@ func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			break
 		}
 		askOrder := askOrders[*askOrderID]
+
+		// 같은 사용자의 주문과는 체결하지 않음 (자기매매 방지)
+		if preventSelfTrade(orderReq, *askOrderID, askOrder, price, depth, depthIndex, bidAskOverLab, executionSeq, remainingQuantity) {
+			continue
+		}
+
 		tradableQuantity := askOrder.Quantity
 		if tradableQuantity > *remainingQuantity { // 체결 가능한 수량이 남은 수량보다 많으면 전부 체결
 			executedQuantity += *remainingQuantity
@@ -846,32 +1182,10 @@ func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매수자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, price, executedQuantity, executionID)
 
 			// 매도자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     askOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *askOrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *askOrderID, price, executedQuantity, executionID)
 
 			processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 			*remainingQuantity = 0
@@ -892,34 +1206,15 @@ func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매수자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, price, executedQuantity, executionID)
 
 			// 매도자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     askOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *askOrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   tradableQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *askOrderID, price, executedQuantity, executionID)
 		}
 
+		// 노출 수량이 모두 체결된 아이스버그 주문은 숨겨진 수량으로 다시 채움
+		replenishIceberg(orderReq.Symbol, *askOrderID, t.SideSell, price, askOrder.CreatedAt, depth, depthIndex, bidAskOverLab, executionSeq)
+
 		// 체결 브로드캐스트
 		broadcastDepth(t.UpdateDepth{
 			Timestamp: timestamp,
@@ -928,7 +1223,7 @@ func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			Price:     price,
 			Quantity:  depth.TotalAsks[price],
 		})
-		broadcastTrade(t.Ledger{
+		broadcastTrade(depth, t.Ledger{
 			Timestamp:   timestamp,
 			Symbol:      orderReq.Symbol,
 			Price:       price,
@@ -936,19 +1231,22 @@ func buyMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			Side:        t.SideBuy,
 			BuyOrderID:  orderReq.OrderID,
 			SellOrderID: *askOrderID,
-			ExecutionID: uuid.NewString(),
+			BuyerID:     orderReq.UserID,
+			SellerID:    askOrder.UserID,
+			ExecutionID: executionID,
 			Conditions:  exchanges.MarketStatus[:2], // pr: 프리장, re: 정규장, po: 포스트장
 		})
 	}
 }
 
-func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string], i btree.Item, remainingQuantity *int) {
+func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], i btree.Item, remainingQuantity *int) {
 	// 가장 높은 매수 호가부터 시작
-	price := float64(i.(t.Float64Item)) // 매수 호가중 가장 높은 가격 가져오기
-	bidOrders := depth.Bids[price]      // 해당 가격대의 모든 매수 주문
+	price := t.Price(i.(t.PriceItem)) // 매수 호가중 가장 높은 가격 가져오기
 	for sequence := (*executionSeq)[t.Bids][price]; sequence != nil && !sequence.IsEmpty() && *remainingQuantity > 0; {
 		executedQuantity := 0 // 체결된 수량
 		timestamp := time.Now().UnixMilli()
+		executionID := uuid.NewString() // 체결 알림과 원장에 같은 체결 ID 사용
+		bidOrders := depth.Bids[price]  // 해당 가격대의 모든 매수 주문 (아이스버그 주문이 다시 채워지면 새로 생성될 수 있음)
 		if len(bidOrders) == 0 {
 			// 혹시 모를 무한루프 방지
 			break
@@ -959,6 +1257,12 @@ func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex
 			break
 		}
 		bidOrder := bidOrders[*bidOrderID]
+
+		// 같은 사용자의 주문과는 체결하지 않음 (자기매매 방지)
+		if preventSelfTrade(orderReq, *bidOrderID, bidOrder, price, depth, depthIndex, bidAskOverLab, executionSeq, remainingQuantity) {
+			continue
+		}
+
 		tradableQuantity := bidOrder.Quantity
 		if tradableQuantity > *remainingQuantity { // 체결 가능한 수량이 남은 수량보다 많으면 전부 체결
 			executedQuantity += *remainingQuantity
@@ -976,32 +1280,10 @@ func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매도자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, price, executedQuantity, executionID)
 
 			// 매수자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     bidOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *bidOrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *bidOrderID, price, executedQuantity, executionID)
 
 			processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 			*remainingQuantity = 0
@@ -1022,34 +1304,15 @@ func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매도자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, price, executedQuantity, executionID)
 
 			// 매수자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     bidOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *bidOrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeMarket,
-				Price:      price,
-				Quantity:   tradableQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *bidOrderID, price, executedQuantity, executionID)
 		}
 
+		// 노출 수량이 모두 체결된 아이스버그 주문은 숨겨진 수량으로 다시 채움
+		replenishIceberg(orderReq.Symbol, *bidOrderID, t.SideBuy, price, bidOrder.CreatedAt, depth, depthIndex, bidAskOverLab, executionSeq)
+
 		// 체결 브로드캐스트
 		broadcastDepth(t.UpdateDepth{
 			Timestamp: timestamp,
@@ -1058,7 +1321,7 @@ func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex
 			Price:     price,
 			Quantity:  depth.TotalBids[price],
 		})
-		broadcastTrade(t.Ledger{
+		broadcastTrade(depth, t.Ledger{
 			Timestamp:   timestamp,
 			Symbol:      orderReq.Symbol,
 			Price:       price,
@@ -1066,22 +1329,34 @@ func sellMarketOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex
 			Side:        t.SideSell,
 			BuyOrderID:  *bidOrderID,
 			SellOrderID: orderReq.OrderID,
-			ExecutionID: uuid.NewString(),
+			BuyerID:     bidOrder.UserID,
+			SellerID:    orderReq.UserID,
+			ExecutionID: executionID,
 			Conditions:  exchanges.MarketStatus[:2], // pr: 프리장, re: 정규장, po: 포스트장
 		})
 	}
 }
 
-func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string], remainingQuantity *int) {
+func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], remainingQuantity *int) {
 	askSeqs := (*executionSeq)[t.Asks][orderReq.Price]
 	for askSeqs != nil && !askSeqs.IsEmpty() {
 		timestamp := time.Now().UnixMilli()
+		executionID := uuid.NewString()  // 체결 알림과 원장에 같은 체결 ID 사용
 		executedQuantity := 0            // 체결된 수량
 		askOrderID := askSeqs.GetFront() // FIFO로 가장 먼저 들어온 주문 ID 가져오기
 		if askOrderID == nil {
 			break
 		}
 		askOrder := depth.Asks[orderReq.Price][*askOrderID]
+
+		// 같은 사용자의 주문과는 체결하지 않음 (자기매매 방지)
+		if preventSelfTrade(orderReq, *askOrderID, askOrder, orderReq.Price, depth, depthIndex, bidAskOverLab, executionSeq, remainingQuantity) {
+			if *remainingQuantity <= 0 {
+				break
+			}
+			continue
+		}
+
 		if askOrder.Quantity >= *remainingQuantity { // 체결 가능한 수량이 남은 수량보다 많으면 전부 체결
 			executedQuantity += *remainingQuantity
 
@@ -1098,32 +1373,10 @@ func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매수자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, orderReq.Price, executedQuantity, executionID)
 
 			// 매도자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     askOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *askOrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *askOrderID, orderReq.Price, executedQuantity, executionID)
 
 			processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 			*remainingQuantity = 0
@@ -1144,34 +1397,15 @@ func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매수자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, orderReq.Price, executedQuantity, executionID)
 
 			// 매도자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     askOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *askOrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   askOrder.Quantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *askOrderID, orderReq.Price, executedQuantity, executionID)
 		}
 
+		// 노출 수량이 모두 체결된 아이스버그 주문은 숨겨진 수량으로 다시 채움
+		replenishIceberg(orderReq.Symbol, *askOrderID, t.SideSell, orderReq.Price, askOrder.CreatedAt, depth, depthIndex, bidAskOverLab, executionSeq)
+
 		// 체결 브로드캐스트
 		broadcastDepth(t.UpdateDepth{
 			Timestamp: timestamp,
@@ -1180,7 +1414,7 @@ func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			Price:     orderReq.Price,
 			Quantity:  depth.TotalAsks[orderReq.Price],
 		})
-		broadcastTrade(t.Ledger{
+		broadcastTrade(depth, t.Ledger{
 			Timestamp:   timestamp,
 			Symbol:      orderReq.Symbol,
 			Price:       orderReq.Price,
@@ -1188,7 +1422,9 @@ func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 			Side:        t.SideBuy,
 			BuyOrderID:  orderReq.OrderID,
 			SellOrderID: *askOrderID,
-			ExecutionID: uuid.NewString(),
+			BuyerID:     orderReq.UserID,
+			SellerID:    askOrder.UserID,
+			ExecutionID: executionID,
 			Conditions:  exchanges.MarketStatus[:2], // pr: 프리장, re: 정규장, po: 포스트장
 		})
 
@@ -1198,16 +1434,26 @@ func buyLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *m
 	}
 }
 
-func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[float64]*utils.Queue[string], remainingQuantity *int) {
+func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], remainingQuantity *int) {
 	bidSeqs := (*executionSeq)[t.Bids][orderReq.Price]
 	for bidSeqs != nil && !bidSeqs.IsEmpty() {
 		timestamp := time.Now().UnixMilli()
+		executionID := uuid.NewString()  // 체결 알림과 원장에 같은 체결 ID 사용
 		executedQuantity := 0            // 체결된 수량
 		bidOrderID := bidSeqs.GetFront() // FIFO로 가장 먼저 들어온 주문 ID 가져오기
 		if bidOrderID == nil {
 			break
 		}
 		bidOrder := depth.Bids[orderReq.Price][*bidOrderID]
+
+		// 같은 사용자의 주문과는 체결하지 않음 (자기매매 방지)
+		if preventSelfTrade(orderReq, *bidOrderID, bidOrder, orderReq.Price, depth, depthIndex, bidAskOverLab, executionSeq, remainingQuantity) {
+			if *remainingQuantity <= 0 {
+				break
+			}
+			continue
+		}
+
 		if bidOrder.Quantity >= *remainingQuantity { // 체결 가능한 수량이 남은 수량보다 많으면 전부 체결
 			executedQuantity += *remainingQuantity
 
@@ -1224,32 +1470,10 @@ func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매도자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, orderReq.Price, executedQuantity, executionID)
 
 			// 매수자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     bidOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *bidOrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *bidOrderID, orderReq.Price, executedQuantity, executionID)
 
 			processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
 			*remainingQuantity = 0
@@ -1270,34 +1494,15 @@ func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			}, depth, depthIndex, bidAskOverLab, executionSeq)
 
 			// 매도자측 우선 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     orderReq.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    orderReq.OrderID,
-				Side:       t.SideSell,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   executedQuantity,
-				Status:     t.StatusPartiallyFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, orderReq.OrderID, orderReq.Price, executedQuantity, executionID)
 
 			// 매수자측 알림
-			notifyUser(t.OrderRequest{
-				Timestamp:  timestamp,
-				UserID:     bidOrder.UserID,
-				Symbol:     orderReq.Symbol,
-				OrderID:    *bidOrderID,
-				Side:       t.SideBuy,
-				OrderType:  t.OrderTypeLimit,
-				Price:      orderReq.Price,
-				Quantity:   bidOrder.Quantity,
-				Status:     t.StatusFilled,
-				ResultChan: nil,
-			})
+			reportFill(depth, orderReq.Symbol, *bidOrderID, orderReq.Price, executedQuantity, executionID)
 		}
 
+		// 노출 수량이 모두 체결된 아이스버그 주문은 숨겨진 수량으로 다시 채움
+		replenishIceberg(orderReq.Symbol, *bidOrderID, t.SideBuy, orderReq.Price, bidOrder.CreatedAt, depth, depthIndex, bidAskOverLab, executionSeq)
+
 		// 체결 브로드캐스트
 		broadcastDepth(t.UpdateDepth{
 			Timestamp: timestamp,
@@ -1306,7 +1511,7 @@ func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			Price:     orderReq.Price,
 			Quantity:  depth.TotalBids[orderReq.Price],
 		})
-		broadcastTrade(t.Ledger{
+		broadcastTrade(depth, t.Ledger{
 			Timestamp:   timestamp,
 			Symbol:      orderReq.Symbol,
 			Price:       orderReq.Price,
@@ -1314,7 +1519,9 @@ func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 			Side:        t.SideSell,
 			BuyOrderID:  *bidOrderID,
 			SellOrderID: orderReq.OrderID,
-			ExecutionID: uuid.NewString(),
+			BuyerID:     bidOrder.UserID,
+			SellerID:    orderReq.UserID,
+			ExecutionID: executionID,
 			Conditions:  exchanges.MarketStatus[:2], // pr: 프리장, re: 정규장, po: 포스트장
 		})
 
@@ -1326,57 +1533,274 @@ func sellLimitOrder(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *
 
 func broadcastDepth(depth t.UpdateDepth) {
 	// 호가 갱신 브로드캐스트
+	if isReplaying(depth.Symbol) {
+		return
+	}
 	if depth.Timestamp == 0 {
 		depth.Timestamp = time.Now().UnixMilli()
 	}
-	jsonDepth, err := json.Marshal(depth)
-	if err != nil {
-		log.Printf("Error marshaling UpdateDepth: %v", err)
+	// 직렬화는 구독자에게 처음 전송할 때 인코딩별로 한 번만 수행
+	ws.DepthHub.BroadcastMessage(depth.Timestamp, ws.DepthPayload(depth))
+}
+
+func broadcastTrade(depth *t.MarketDepth, ledger t.Ledger) {
+	// 체결 내역 기록 (최종 체결가는 복구 중에도 갱신해야 스탑 주문이 같은 순서로 발동됨)
+	depth.LastPrice = ledger.Price
+	if ledger.Timestamp == 0 {
+		ledger.Timestamp = time.Now().UnixMilli()
+	}
+	if isReplaying(ledger.Symbol) {
+		// 복구 중 다시 발생한 체결은 이미 전송 및 저장된 체결이므로 생략 (임시 원장은 restoreTempLedger 에서 DB로 복구)
 		return
 	}
-	ws.DepthHub.BroadcastMessage(depth.Timestamp, websocket.TextMessage, jsonDepth)
+	ws.LedgerHub.BroadcastMessage(ledger.Timestamp, ws.LedgerPayload(ledger))
+	ws.AppendTempLedger(ledger)
+	recordTrade(ledger)
 }
 
-func notifyUser(notify t.OrderRequest) {
-	// 주문 알림
-	if notify.Timestamp == 0 {
-		notify.Timestamp = time.Now().UnixMilli()
+// recordTrade 체결 원시 데이터 DB 저장 (비동기, 복구 중에는 저장하지 않음)
+func recordTrade(ledger t.Ledger) {
+	if TW != nil && shouldPersist(ledger.Symbol) {
+		TW.Add(postgresql.Trade{
+			ExecutionID: ledger.ExecutionID,
+			Timestamp:   time.UnixMilli(ledger.Timestamp),
+			Symbol:      ledger.Symbol,
+			Price:       int64(ledger.Price),
+			Volume:      ledger.Volume,
+			Side:        ledger.Side,
+			BuyOrderID:  ledger.BuyOrderID,
+			SellOrderID: ledger.SellOrderID,
+			BuyerID:     ledger.BuyerID,
+			SellerID:    ledger.SellerID,
+			Conditions:  ledger.Conditions,
+		})
 	}
-	jsonNotify, err := json.Marshal(notify)
+}
+
+// RestoreExchange 서버가 장중 다운되었다가 복구 되었을 때 작동하는 함수 (매칭 고루틴 시작 전에 호출할 것)
+func (sh *SymbolShard) RestoreExchange() {
+	/*
+		시장가 주문은 모두 취소 처리
+		지정가 주문은 모두 호가에 복구
+		만약 매수 호가와 매도 호가가 동시에 존재하는 가격대가 있는경우 체결 처리 하기
+	*/
+	if sh.journal == nil {
+		return
+	}
+
+	snapshot, err := sh.journal.ReadSnapshot()
 	if err != nil {
-		log.Printf("Error marshaling OrderRequest for notification: %v", err)
+		log.Printf("Error reading book snapshot for %s: %v", sh.Symbol, err)
+	}
+	entries, err := sh.journal.ReadEntries()
+	if err != nil {
+		log.Printf("Error reading order journal for %s: %v", sh.Symbol, err)
+	}
+	if snapshot == nil && len(entries) == 0 {
 		return
 	}
-	ws.NotifyHub.SendMessageToUser(notify.UserID, notify.Timestamp, websocket.TextMessage, jsonNotify)
 
-	// TODO 주문 알림 DB 저장 (비동기)
+	replayingSymbols.Store(sh.Symbol, true)
+
+	// 스냅샷의 지정가 주문을 시간 우선 순서대로 호가에 복구
+	if snapshot != nil {
+		restore := func(side string, orders []SnapshotOrder) {
+			for _, order := range orders {
+				orderReq := t.OrderRequest{
+					UserID:    order.UserID,
+					OrderID:   order.OrderID,
+					Symbol:    sh.Symbol,
+					Status:    t.StatusOpen,
+					Side:      side,
+					OrderType: t.OrderTypeLimit,
+					Price:     order.Price,
+					Quantity:  order.Quantity,
+				}
+				processOpen(&orderReq, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+				keepOrderInfo(order.OrderID, t.Order{CreatedAt: order.CreatedAt}, &sh.Depth, &sh.DepthOrderIDIndex)
+
+				// 체결 상태 복구 (이전 스냅샷에는 없으므로 남은 수량을 주문 수량으로 사용)
+				orderQuantity := order.OrderQuantity
+				if orderQuantity == 0 {
+					orderQuantity = order.Quantity
+				}
+				sh.Depth.Executions[order.OrderID] = &t.OrderExecution{
+					UserID:              order.UserID,
+					ClientOrderID:       order.ClientOrderID,
+					Side:                side,
+					OrderType:           t.OrderTypeLimit,
+					Price:               order.Price,
+					TimeInForce:         order.TimeInForce,
+					ExpireAt:            order.ExpireAt,
+					PostOnly:            order.PostOnly,
+					DisplayQuantity:     order.DisplayQuantity,
+					SelfTradePrevention: order.SelfTradePrevention,
+					Quantity:            orderQuantity,
+					CumQuantity:         order.CumQuantity,
+					CumAmount:           order.CumAmount,
+				}
+				rememberClientOrder(order.UserID, order.ClientOrderID, sh.Symbol, order.OrderID)
+			}
+		}
+		restore(t.SideBuy, snapshot.Bids)
+		restore(t.SideSell, snapshot.Asks)
+
+		// 스탑 주문은 발동 순서대로 대기열에 복구
+		sh.Depth.LastPrice = snapshot.LastPrice
+		sh.auction = snapshot.Auction
+		for _, stop := range snapshot.Stops {
+			stop := stop
+			sh.Stops.add(&stop)
+			sh.Depth.Executions[stop.Order.OrderID] = &t.OrderExecution{
+				UserID:              stop.Order.UserID,
+				ClientOrderID:       stop.Order.ClientOrderID,
+				Side:                stop.Order.Side,
+				OrderType:           stop.Order.OrderType,
+				Price:               stop.Order.Price,
+				StopPrice:           stop.Order.StopPrice,
+				TimeInForce:         stop.Order.TimeInForce,
+				ExpireAt:            stop.Order.ExpireAt,
+				Quantity:            stop.Order.Quantity,
+				SelfTradePrevention: stop.Order.SelfTradePrevention,
+			}
+			rememberClientOrder(stop.Order.UserID, stop.Order.ClientOrderID, sh.Symbol, stop.Order.OrderID)
+		}
+	}
+
+	// 처리가 끝났던 주문은 그대로 다시 처리 (알림 없이)
+	done := make(map[int64]bool)
+	for _, entry := range entries {
+		if entry.Type == JournalEntryDone {
+			done[entry.Seq] = true
+		}
+	}
+	var inFlight []t.OrderRequest
+	restored := 0
+	for _, entry := range entries {
+		if snapshot != nil && entry.Seq <= snapshot.Seq {
+			continue
+		}
+		if entry.Type == JournalEntryPhase {
+			// 처리가 끝난 단일가 매매 단계 변경만 다시 처리 (도중에 다운된 경우는 복구 후 현재 세션으로 다시 변경)
+			if done[entry.Seq] {
+				sh.setAuction(entry.Phase)
+			}
+			continue
+		}
+		if entry.Type != JournalEntryOrder || entry.Order == nil {
+			continue
+		}
+		if !done[entry.Seq] {
+			inFlight = append(inFlight, *entry.Order)
+			continue
+		}
+		orderReq := *entry.Order
+		orderReq.ResultChan = make(chan t.Result, 1)
+		sh.processOrderRequest(orderReq)
+		restored++
+	}
+
+	replayingSymbols.Delete(sh.Symbol)
+
+	// 복구된 호가로 저널 압축
+	sh.writeSnapshot()
+
+	// 처리 도중 다운된 주문: 시장가 주문은 취소, 나머지는 다시 처리
+	for _, orderReq := range inFlight {
+		if orderReq.Status == t.StatusOpen && orderReq.OrderType == t.OrderTypeMarket {
+			orderReq.Timestamp = time.Now().UnixMilli()
+			orderReq.Status = t.StatusCanceled
+			reportCancel(&sh.Depth, &orderReq, "Canceled due to server restart")
+			continue
+		}
+		orderReq.ResultChan = make(chan t.Result, 1)
+		sh.processOrderRequest(orderReq)
+	}
+
+	// 매수 호가와 매도 호가가 겹치는 가격대 체결 처리
+	sh.recrossBook()
+
+	// 복구 중 최종 체결가에 도달한 스탑 주문 발동
+	sh.triggerStops()
+
+	// 서버가 멈춰있는 동안 만료 시각이 지난 GTD 주문 만료
+	sh.expireOrders(time.Now().UnixMilli(), false)
+
+	// 복구된 호가 전체 브로드캐스트
+	sh.broadcastBook()
+
+	log.Printf("Restored order book for %s (%d journal entries, %d in-flight orders)", sh.Symbol, restored, len(inFlight))
 }
 
-func broadcastTrade(ledger t.Ledger) {
-	// 체결 내역 기록
-	if ledger.Timestamp == 0 {
-		ledger.Timestamp = time.Now().UnixMilli()
+// restoreTempLedger 서버가 재시작된 경우 오늘 저장된 체결 내역으로 임시 원장 복구 (현재가 계산 및 봉 데이터용)
+func (sh *SymbolShard) restoreTempLedger() {
+	if ledger := ws.GetTempLedger(sh.Symbol); ledger != nil && ledger.Size() != 0 {
+		return
 	}
-	jsonLedger, err := json.Marshal(ledger)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	trades, err := postgresApp.Get().TradeRepo().GetTradesSince(context.Background(), sh.Symbol, today)
 	if err != nil {
-		log.Printf("Error marshaling Ledger: %v", err)
+		log.Printf("Error restoring ledger for %s: %v", sh.Symbol, err)
 		return
 	}
-	ws.LedgerHub.BroadcastMessage(ledger.Timestamp, websocket.TextMessage, jsonLedger)
-	if ws.TempLedger[ledger.Symbol] == nil {
-		ws.TempLedger[ledger.Symbol] = utils.NewChunkedStore[t.Ledger](128)
+	for _, trade := range trades {
+		ws.AppendTempLedger(t.Ledger{
+			Timestamp:   trade.Timestamp.UnixMilli(),
+			Symbol:      trade.Symbol,
+			Price:       t.Price(trade.Price),
+			Volume:      trade.Volume,
+			Side:        trade.Side,
+			ExecutionID: trade.ExecutionID,
+			BuyOrderID:  trade.BuyOrderID,
+			SellOrderID: trade.SellOrderID,
+			BuyerID:     trade.BuyerID,
+			SellerID:    trade.SellerID,
+			Conditions:  trade.Conditions,
+		})
 	}
-	ws.TempLedger[ledger.Symbol].Append(ledger)
-
-	// TODO 체결 원시 데이터 DB 저장 (비동기)
 }
 
-func RestoreExchange() {
-	// 서버가 장중 다운되었다가 복구 되었을 때 작동하는 함수
+// recrossBook 최우선 매수 호가가 최우선 매도 호가 이상인 동안 매수 주문을 다시 넣어 체결
+func (sh *SymbolShard) recrossBook() {
+	for i := 0; i < len(sh.DepthOrderIDIndex)+1; i++ { // 혹시 모를 무한루프 방지
+		if sh.auction != "" {
+			// 단일가 매매 중에는 겹치는 호가를 그대로 두고 단일가 매매 종료 시 체결
+			return
+		}
+		highBid := sh.Depth.BidTree.Max()
+		lowAsk := sh.Depth.AskTree.Min()
+		if highBid == nil || lowAsk == nil || highBid.(t.PriceItem) < lowAsk.(t.PriceItem) {
+			return
+		}
+
+		price := t.Price(highBid.(t.PriceItem))
+		seq := sh.DepthExecutionSeq[t.Bids][price]
+		if seq == nil || seq.IsEmpty() {
+			return
+		}
+		orderID := *seq.GetFront()
+		index := sh.DepthOrderIDIndex[orderID]
+		if index == nil {
+			return
+		}
 
-	/*
-		시장가 주문은 모두 취소 처리
-		지정가 주문은 모두 호가에 복구
-		만약 매수 호가와 매도 호가가 동시에 존재하는 가격대가 있는경우 체결 처리 하기
-	*/
+		orderReq := t.OrderRequest{
+			Timestamp: time.Now().UnixMilli(),
+			UserID:    index[0].(int),
+			OrderID:   orderID,
+			Symbol:    sh.Symbol,
+			Status:    t.StatusOpen,
+			Side:      t.SideBuy,
+			OrderType: t.OrderTypeLimit,
+			Price:     price,
+			Quantity:  leavesQuantity(orderID, &sh.Depth, &sh.DepthOrderIDIndex),
+		}
+		previous, _ := lookupOrder(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+		processCancel(&orderReq, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+		processOpen(visibleOrder(&orderReq, &sh.Depth), &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq)
+		keepOrderInfo(orderID, previous, &sh.Depth, &sh.DepthOrderIDIndex)
+		sh.matchOrder(&orderReq)
+	}
 }
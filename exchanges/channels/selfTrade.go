@@ -0,0 +1,128 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// ValidSelfTradePrevention 자기매매 방지 모드 검증 (빈 값은 사용하지 않음)
+func ValidSelfTradePrevention(mode string) bool {
+	switch mode {
+	case "", t.STPNone, t.STPCancelNewest, t.STPCancelOldest, t.STPCancelBoth, t.STPDecrement:
+		return true
+	}
+	return false
+}
+
+// normalizeSelfTradePrevention 신규 주문의 자기매매 방지 모드 검증 ("none" 은 빈 값으로 저장)
+func normalizeSelfTradePrevention(orderReq *t.OrderRequest) (string, string) {
+	if !ValidSelfTradePrevention(orderReq.SelfTradePrevention) {
+		return t.ReasonInvalidSTP, "Invalid SelfTradePrevention"
+	}
+	if orderReq.SelfTradePrevention == t.STPNone {
+		orderReq.SelfTradePrevention = ""
+	}
+	return "", ""
+}
+
+// preventSelfTrade 들어온 주문이 같은 사용자의 호가 주문과 체결되려 하면 들어온 주문의 자기매매 방지 모드로 처리
+// 처리한 경우 true (체결하지 않음), 들어온 주문이 취소되면 remainingQuantity 는 0
+func preventSelfTrade(orderReq *t.OrderRequest, restingID string, resting t.Order, price t.Price, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], remainingQuantity *int) bool {
+	mode := orderReq.SelfTradePrevention
+	if mode == "" || resting.UserID != orderReq.UserID {
+		return false
+	}
+
+	restingReq := &t.OrderRequest{
+		Timestamp: time.Now().UnixMilli(),
+		UserID:    resting.UserID,
+		Symbol:    orderReq.Symbol,
+		OrderID:   restingID,
+		Side:      t.SideSell,
+		Price:     price,
+		Quantity:  resting.Quantity,
+	}
+	restingBook := t.Asks
+	if orderReq.Side == t.SideSell {
+		restingReq.Side = t.SideBuy
+		restingBook = t.Bids
+	}
+
+	cancelResting, cancelIncoming := false, false
+	switch mode {
+	case t.STPCancelNewest:
+		cancelIncoming = true
+	case t.STPCancelOldest:
+		cancelResting = true
+	case t.STPCancelBoth:
+		cancelResting, cancelIncoming = true, true
+	case t.STPDecrement:
+		// 겹치는 수량만큼 두 주문 모두 줄이고, 남은 수량이 없는 주문은 취소
+		restingLeaves := leavesQuantity(restingID, depth, depthIndex)
+		decrement := min(*remainingQuantity, restingLeaves)
+		cancelResting = decrement >= restingLeaves
+		cancelIncoming = decrement >= *remainingQuantity
+
+		if !cancelResting {
+			if exec, ok := depth.Executions[restingID]; ok {
+				exec.Quantity -= decrement
+			}
+			// 노출 수량이 남은 수량보다 많으면 줄임 (가격이 같고 수량만 줄어드므로 우선순위 유지)
+			if visible := (*depthIndex)[restingID][3].(int); visible > restingLeaves-decrement {
+				restingReq.Quantity = restingLeaves - decrement
+				restingReq.Status = t.StatusModified
+				processModify(restingReq, depth, depthIndex, bidAskOverLab, executionSeq)
+			}
+			reportSelfTrade(depth, restingReq, mode, orderReq.OrderID, false)
+		}
+		if !cancelIncoming {
+			*remainingQuantity -= decrement
+			if exec, ok := depth.Executions[orderReq.OrderID]; ok {
+				exec.Quantity -= decrement
+			}
+			reportSelfTrade(depth, orderReq, mode, restingID, false)
+		}
+	}
+
+	if cancelResting {
+		processCancel(restingReq, depth, depthIndex, bidAskOverLab, executionSeq)
+		reportSelfTrade(depth, restingReq, mode, orderReq.OrderID, true)
+	}
+	update := t.UpdateDepth{
+		Timestamp: restingReq.Timestamp,
+		Symbol:    orderReq.Symbol,
+		Side:      restingBook,
+		Price:     price,
+	}
+	if restingBook == t.Bids {
+		update.Quantity = depth.TotalBids[price]
+	} else {
+		update.Quantity = depth.TotalAsks[price]
+	}
+	broadcastDepth(update)
+
+	if cancelIncoming {
+		// 들어온 주문의 남은 수량 취소 (호가 갱신은 호출한 쪽에서 처리)
+		processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+		reportSelfTrade(depth, orderReq, mode, restingID, true)
+		*remainingQuantity = 0
+	}
+	return true
+}
+
+// selfTradeQuantity 자기매매 방지 모드인 주문이 체결할 수 없는 같은 사용자의 호가 수량 (FOK 체결 가능 수량 계산용)
+func selfTradeQuantity(orderReq *t.OrderRequest, orders map[string]t.Order) int {
+	if orderReq.SelfTradePrevention == "" {
+		return 0
+	}
+	total := 0
+	for _, order := range orders {
+		if order.UserID == orderReq.UserID {
+			total += order.Quantity
+		}
+	}
+	return total
+}
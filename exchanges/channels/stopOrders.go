@@ -0,0 +1,303 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// StopOrder 발동 대기 중인 스탑 주문 (호가에 올라가지 않음)
+type StopOrder struct {
+	Order     t.OrderRequest `json:"order"`
+	CreatedAt int64          `json:"created_at"`
+	UpdatedAt int64          `json:"updated_at"`
+}
+
+// StopBook 심볼별 스탑 주문 대기열 (발동 가격 우선, 시간 우선)
+type StopBook struct {
+	Orders   map[string]*StopOrder                       // 주문 ID별 스탑 주문
+	Seq      map[string]map[t.Price]*utils.Queue[string] // 방향별, 발동 가격별 주문 순서 (예: {"buy": {1000: ["orderID1"]}})
+	BuyTree  *btree.BTree                                // 매수 스탑 발동 가격 (최종 체결가 >= 발동 가격이면 발동)
+	SellTree *btree.BTree                                // 매도 스탑 발동 가격 (최종 체결가 <= 발동 가격이면 발동)
+}
+
+func NewStopBook() *StopBook {
+	return &StopBook{
+		Orders: make(map[string]*StopOrder),
+		Seq: map[string]map[t.Price]*utils.Queue[string]{
+			t.SideBuy:  make(map[t.Price]*utils.Queue[string]),
+			t.SideSell: make(map[t.Price]*utils.Queue[string]),
+		},
+		BuyTree:  btree.New(4),
+		SellTree: btree.New(4),
+	}
+}
+
+func isStopOrder(orderType string) bool {
+	return orderType == t.OrderTypeStop || orderType == t.OrderTypeStopLimit
+}
+
+func (sb *StopBook) tree(side string) *btree.BTree {
+	if side == t.SideBuy {
+		return sb.BuyTree
+	}
+	return sb.SellTree
+}
+
+// add 스탑 주문 추가 (같은 발동 가격에서는 먼저 들어온 주문이 먼저 발동)
+func (sb *StopBook) add(stop *StopOrder) {
+	side := stop.Order.Side
+	price := stop.Order.StopPrice
+	if sb.Seq[side][price] == nil {
+		sb.Seq[side][price] = utils.NewQueue[string]()
+	}
+	sb.Seq[side][price].Enqueue(stop.Order.OrderID)
+	sb.tree(side).ReplaceOrInsert(t.PriceItem(price))
+	sb.Orders[stop.Order.OrderID] = stop
+}
+
+// remove 스탑 주문 삭제 (없으면 nil)
+func (sb *StopBook) remove(orderID string) *StopOrder {
+	stop, ok := sb.Orders[orderID]
+	if !ok {
+		return nil
+	}
+	side := stop.Order.Side
+	price := stop.Order.StopPrice
+	if seq := sb.Seq[side][price]; seq != nil {
+		seq.RemoveValue(orderID)
+		if seq.IsEmpty() {
+			delete(sb.Seq[side], price)
+			sb.tree(side).Delete(t.PriceItem(price))
+		}
+	}
+	delete(sb.Orders, orderID)
+	return stop
+}
+
+// popTriggered 최종 체결가로 발동된 스탑 주문을 하나 꺼냄 (없으면 nil)
+func (sb *StopBook) popTriggered(lastPrice t.Price) *StopOrder {
+	if lastPrice <= 0 {
+		return nil
+	}
+
+	var triggered t.Price
+	side := ""
+	if low := sb.BuyTree.Min(); low != nil && t.Price(low.(t.PriceItem)) <= lastPrice {
+		side, triggered = t.SideBuy, t.Price(low.(t.PriceItem))
+	} else if high := sb.SellTree.Max(); high != nil && t.Price(high.(t.PriceItem)) >= lastPrice {
+		side, triggered = t.SideSell, t.Price(high.(t.PriceItem))
+	} else {
+		return nil
+	}
+
+	orderID := sb.Seq[side][triggered].GetFront()
+	if orderID == nil {
+		// 혹시 모를 무한루프 방지
+		delete(sb.Seq[side], triggered)
+		sb.tree(side).Delete(t.PriceItem(triggered))
+		return sb.popTriggered(lastPrice)
+	}
+	return sb.remove(*orderID)
+}
+
+// values 발동 순서대로 스탑 주문 목록 (스냅샷용)
+func (sb *StopBook) values() []StopOrder {
+	var stops []StopOrder
+	collect := func(side string) func(i btree.Item) bool {
+		return func(i btree.Item) bool {
+			for _, orderID := range sb.Seq[side][t.Price(i.(t.PriceItem))].Values() {
+				if stop, ok := sb.Orders[orderID]; ok {
+					stops = append(stops, *stop)
+				}
+			}
+			return true
+		}
+	}
+	sb.BuyTree.Ascend(collect(t.SideBuy))
+	sb.SellTree.Descend(collect(t.SideSell))
+	return stops
+}
+
+// processStopRequest 발동 대기 중인 스탑 주문의 정정, 취소 처리
+func (sh *SymbolShard) processStopRequest(orderReq t.OrderRequest, stop *StopOrder) {
+	timestamp := time.Now().UnixMilli()
+	depth := &sh.Depth
+
+	reject := func(code int, reasonCode, message string) {
+		sendResult(orderReq.ResultChan, t.Result{
+			Timestamp:  timestamp,
+			Success:    false,
+			Message:    message,
+			Code:       code,
+			ReasonCode: reasonCode,
+		})
+		reportReject(depth, &orderReq, reasonCode, message)
+	}
+
+	// 입력 검증 | UserID, Side, OrderType, Price, StopPrice, Quantity
+	if stop.Order.UserID != orderReq.UserID {
+		reject(400, t.ReasonOrderNotOwned, "OrderID does not match UserID")
+		return
+	}
+	if stop.Order.Side != orderReq.Side {
+		reject(400, t.ReasonInvalidOrderID, "Invalid OrderID")
+		return
+	}
+
+	if orderReq.Status == t.StatusModified {
+		// 빈값은 기존 값 유지
+		if orderReq.OrderType == "" {
+			orderReq.OrderType = stop.Order.OrderType
+		}
+		if orderReq.StopPrice == 0 {
+			orderReq.StopPrice = stop.Order.StopPrice
+		}
+		if orderReq.Price == 0 && orderReq.OrderType == t.OrderTypeStopLimit {
+			orderReq.Price = stop.Order.Price
+		}
+		if orderReq.OrderType == t.OrderTypeStop {
+			orderReq.Price = 0
+		}
+
+		if !isStopOrder(orderReq.OrderType) { // 발동 전에는 스탑 주문끼리만 변경 가능
+			reject(400, t.ReasonInvalidOrderType, "Invalid OrderType")
+			return
+		}
+		if orderReq.StopPrice < 0 {
+			reject(400, t.ReasonInvalidStopPrice, "Invalid StopPrice")
+			return
+		}
+		if orderReq.Price <= 0 && orderReq.OrderType == t.OrderTypeStopLimit {
+			reject(400, t.ReasonInvalidPrice, "Invalid Price")
+			return
+		}
+		if orderReq.Quantity <= 0 {
+			reject(400, t.ReasonInvalidQuantity, "Invalid Quantity")
+			return
+		}
+		if !isReplaying(sh.Symbol) {
+			if code, message := sh.params.validate(&orderReq); code != "" {
+				reject(400, code, message)
+				return
+			}
+		}
+		if orderReq.OrderType == stop.Order.OrderType &&
+			orderReq.Price == stop.Order.Price &&
+			orderReq.StopPrice == stop.Order.StopPrice &&
+			orderReq.Quantity == stop.Order.Quantity {
+			reject(400, t.ReasonNoChanges, "No changes in Price or Quantity")
+			return
+		}
+	} else {
+		// 취소 주문은 이전 주문 정보 유지
+		orderReq.OrderType = stop.Order.OrderType
+		orderReq.Price = stop.Order.Price
+		orderReq.StopPrice = stop.Order.StopPrice
+		orderReq.Quantity = stop.Order.Quantity
+	}
+
+	// 유효 기간은 정정할 수 없음
+	orderReq.TimeInForce = stop.Order.TimeInForce
+	orderReq.ExpireAt = stop.Order.ExpireAt
+	orderReq.PostOnly = ""
+	orderReq.SelfTradePrevention = stop.Order.SelfTradePrevention
+	orderReq.ClientOrderID = stop.Order.ClientOrderID
+
+	if orderReq.Timestamp == 0 || !isReplaying(sh.Symbol) {
+		orderReq.Timestamp = timestamp
+	}
+
+	done, err := sh.journalRequest(orderReq)
+	if err != nil {
+		reject(500, t.ReasonInternalError, "Failed to record order")
+		return
+	}
+	defer done()
+
+	sendResult(orderReq.ResultChan, t.Result{
+		Timestamp: timestamp,
+		Success:   true,
+		Message:   "Order processed successfully",
+		Code:      200,
+	})
+
+	sh.Stops.remove(orderReq.OrderID)
+	switch orderReq.Status {
+	case t.StatusModified:
+		// 정정된 스탑 주문은 발동 순서 재조정
+		modified := orderReq
+		modified.Status = t.StatusOpen
+		modified.ResultChan = nil
+		sh.Stops.add(&StopOrder{
+			Order:     modified,
+			CreatedAt: stop.CreatedAt,
+			UpdatedAt: orderReq.Timestamp,
+		})
+		reportAccepted(depth, &orderReq)
+
+		// 정정된 발동 가격에 이미 도달한 경우 바로 발동
+		sh.triggerStops()
+	case t.StatusCanceled:
+		reportAccepted(depth, &orderReq)
+	}
+}
+
+// triggerStops 최종 체결가가 발동 가격에 도달한 스탑 주문을 시장가 / 지정가 주문으로 전환해서 처리
+func (sh *SymbolShard) triggerStops() {
+	if sh.auction != "" {
+		// 단일가 매매 중에는 발동하지 않음 (단일가 체결 후 발동)
+		return
+	}
+	for n := len(sh.Stops.Orders); n >= 0; n-- { // 혹시 모를 무한루프 방지
+		stop := sh.Stops.popTriggered(sh.Depth.LastPrice)
+		if stop == nil {
+			return
+		}
+		sh.activateStop(stop.Order)
+	}
+}
+
+// activateStop 발동된 스탑 주문을 일반 주문과 같은 경로(processOpen -> processOrder)로 처리
+func (sh *SymbolShard) activateStop(orderReq t.OrderRequest) {
+	depth := &sh.Depth
+	depthOrderIDIndex := sh.DepthOrderIDIndex
+	depthExecutionSeq := sh.DepthExecutionSeq
+
+	if orderReq.OrderType == t.OrderTypeStop {
+		// 시장가 주문은 IOC, FOK 만 가능 (DAY, GTC, GTD 는 발동 전까지만 적용)
+		orderReq.OrderType = t.OrderTypeMarket
+		orderReq.Price = 0
+		if orderReq.TimeInForce != t.TimeInForceFOK {
+			orderReq.TimeInForce = t.TimeInForceIOC
+		}
+		orderReq.MarketOrderType = orderReq.TimeInForce
+	} else {
+		orderReq.OrderType = t.OrderTypeLimit
+	}
+	orderReq.Status = t.StatusOpen
+	orderReq.Timestamp = time.Now().UnixMilli()
+	orderReq.ResultChan = nil
+
+	processOpen(&orderReq, depth, &depthOrderIDIndex, sh.BidAskOverlapCheck, &depthExecutionSeq)
+	if orderReq.OrderType == t.OrderTypeLimit { // 지정가 주문일때만 브로드캐스트
+		update := t.UpdateDepth{
+			Timestamp: orderReq.Timestamp,
+			Symbol:    orderReq.Symbol,
+			Price:     orderReq.Price,
+		}
+		if orderReq.Side == t.SideBuy {
+			update.Side = t.Bids
+			update.Quantity = depth.TotalBids[orderReq.Price]
+		} else {
+			update.Side = t.Asks
+			update.Quantity = depth.TotalAsks[orderReq.Price]
+		}
+		broadcastDepth(update)
+	}
+	reportTriggered(depth, &orderReq)
+
+	sh.matchOrder(&orderReq)
+}
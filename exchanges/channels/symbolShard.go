@@ -0,0 +1,466 @@
+package channels
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/exchanges"
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// SymbolShard 심볼 하나를 전담하는 매칭 엔진 (전용 주문 큐 + 호가 상태)
+type SymbolShard struct {
+	Symbol           string
+	OrderRequestChan chan t.OrderRequest
+
+	runLock sync.RWMutex // running 과 tasks 전송 보호 (종료 후 실행되지 않는 작업이 큐에 남지 않도록)
+	running bool         // 매칭 고루틴 실행 여부
+
+	bookLock sync.Mutex // 호가 상태 보호 (매칭 고루틴이 처리하는 동안, 멈춘 샤드에서 작업을 바로 실행하는 동안 잡음)
+
+	journal *OrderJournal // 장애 복구용 주문 저널 (nil이면 기록하지 않음)
+	params  symbolParams  // 주문 검증용 심볼 설정 (매칭 고루틴 안에서만 읽고 씀)
+	auction string        // 진행 중인 단일가 매매 단계 ("" 이면 접속 매매, 매칭 고루틴 안에서만 읽고 씀)
+
+	volatilityUntil int64 // 변동성 완화장치 종료 시각 (unix milliseconds)
+
+	tasks chan func()   // 매칭 고루틴 안에서 실행할 작업 (호가 초기화 등)
+	quit  chan struct{} // 매칭 고루틴 종료 신호
+	done  chan struct{} // 매칭 고루틴 종료 완료
+
+	Depth              t.MarketDepth                               // 호가 데이터 (예: {Bids: [...], Asks: [...]})
+	DepthOrderIDIndex  map[string][]interface{}                    // 주문 ID 인덱스 (예: {"orderID1": [1, "bids", 123.45, 10], "orderID2": [2, "asks", 678.90, 20]})
+	DepthExecutionSeq  map[string]map[t.Price]*utils.Queue[string] // 가격대별 주문 순서 ID 리스트 (예: {"bids": {123.45: ["orderID1", "orderID3"]}, "asks": {678.90: ["orderID5", "orderID6"]}})
+	BidAskOverlapCheck *btree.BTree                                // 매수/매도 가격 중복 체크용
+	Stops              *StopBook                                   // 발동 대기 중인 스탑 주문
+}
+
+func NewSymbolShard(symbol string) *SymbolShard {
+	sh := &SymbolShard{
+		Symbol:           symbol,
+		OrderRequestChan: make(chan t.OrderRequest, 500),
+		tasks:            make(chan func(), 16),
+	}
+	sh.resetBook()
+	return sh
+}
+
+// symbolParams 주문 검증에 사용하는 심볼 설정 (주문마다 DB를 조회하지 않도록 샤드에 보관)
+type symbolParams struct {
+	TickSize             t.Price // 호가 단위 (0이면 검증하지 않음)
+	MinimumOrderQuantity int     // 최소 주문 수량 (0이면 검증하지 않음)
+	UpperLimit           t.Price // 일일 상한가 (0이면 검증하지 않음)
+	LowerLimit           t.Price // 일일 하한가
+	VolatilityBand       float64 // 변동성 완화장치 발동 기준 (직전 체결가 대비 %, 0이면 사용 안함)
+	ReferencePrice       t.Price // 기준가 (전일 종가, 종가가 없으면 공모가)
+	Index                bool    // 지수 심볼 여부 (서킷 브레이커 발동 기준)
+}
+
+// loadSymbolParams DB에서 심볼 설정 조회
+func loadSymbolParams(symbol string) (symbolParams, error) {
+	sym, err := postgresApp.Get().SymbolRepo().GetSymbolData(context.Background(), symbol)
+	if err != nil {
+		return symbolParams{}, err
+	}
+	// float32 -> float64 변환 오차 제거 (예: 0.01 -> 0.009999999776)
+	tickSize, _ := strconv.ParseFloat(strconv.FormatFloat(float64(sym.TickSize), 'f', -1, 32), 64)
+	params := symbolParams{
+		TickSize:             exchanges.ToPrice(tickSize),
+		MinimumOrderQuantity: int(math.Ceil(float64(sym.MinimumOrderQuantity))),
+		VolatilityBand:       float64(sym.VolatilityBandPercent),
+		Index:                sym.Type == "index",
+	}
+
+	// 기준가 (전일 종가, 종가가 없으면 공모가)
+	base, err := postgresApp.Get().SymbolRepo().GetClosePrice(context.Background(), symbol)
+	if err != nil || base <= 0 {
+		base = int64(exchanges.ToPrice(sym.IPOPrice))
+	}
+	params.ReferencePrice = t.Price(base)
+
+	// 일일 가격 제한폭 (기준가 대비)
+	if sym.PriceLimitPercent > 0 {
+		params.UpperLimit, params.LowerLimit = priceLimits(params.ReferencePrice, float64(sym.PriceLimitPercent), params.TickSize)
+	}
+	return params, nil
+}
+
+// priceLimits 기준가 대비 제한폭으로 상한가, 하한가 계산 (호가 단위에 맞춰 범위 안쪽으로 맞춤)
+func priceLimits(base t.Price, percent float64, tickSize t.Price) (t.Price, t.Price) {
+	if base <= 0 {
+		return 0, 0
+	}
+	if tickSize <= 0 {
+		tickSize = 1
+	}
+	band := t.Price(float64(base) * percent / 100)
+	upper := (base + band) / tickSize * tickSize
+	lower := (base - band + tickSize - 1) / tickSize * tickSize
+	if lower < tickSize {
+		lower = tickSize
+	}
+	return upper, lower
+}
+
+// validate 호가 단위, 최소 주문 수량 검증 (통과하면 빈 사유 코드 반환)
+func (p symbolParams) validate(orderReq *t.OrderRequest) (string, string) {
+	if orderReq.Status == t.StatusCanceled {
+		return "", ""
+	}
+	if p.TickSize > 0 && orderReq.OrderType != t.OrderTypeMarket && orderReq.Price%p.TickSize != 0 {
+		return t.ReasonInvalidTickSize, fmt.Sprintf("Price must be a multiple of tick size %d", p.TickSize)
+	}
+	if p.TickSize > 0 && orderReq.StopPrice%p.TickSize != 0 {
+		return t.ReasonInvalidTickSize, fmt.Sprintf("StopPrice must be a multiple of tick size %d", p.TickSize)
+	}
+	if p.UpperLimit > 0 && orderReq.OrderType != t.OrderTypeMarket && orderReq.OrderType != t.OrderTypeStop &&
+		(orderReq.Price > p.UpperLimit || orderReq.Price < p.LowerLimit) {
+		return t.ReasonPriceOutOfLimit, fmt.Sprintf("Price must be between %d and %d", p.LowerLimit, p.UpperLimit)
+	}
+	if p.MinimumOrderQuantity > 0 && orderReq.Status == t.StatusOpen && orderReq.Quantity < p.MinimumOrderQuantity {
+		return t.ReasonBelowMinimumQuantity, fmt.Sprintf("Quantity must be at least %d", p.MinimumOrderQuantity)
+	}
+	return "", ""
+}
+
+// refreshParams DB에서 심볼 설정을 다시 읽어 매칭 고루틴 안에서 반영
+func (sh *SymbolShard) refreshParams() {
+	params, err := loadSymbolParams(sh.Symbol)
+	if err != nil {
+		log.Printf("Error loading symbol parameters for %s: %v", sh.Symbol, err)
+		return
+	}
+	sh.runTask(func() {
+		sh.params = params
+	})
+}
+
+// resetBook 호가 상태 초기화 (반드시 매칭 고루틴 안에서 또는 고루틴 시작 전에 호출할 것)
+func (sh *SymbolShard) resetBook() {
+	sh.Depth = t.MarketDepth{
+		Bids:      make(map[t.Price]map[string]t.Order),
+		Asks:      make(map[t.Price]map[string]t.Order),
+		TotalBids: make(map[t.Price]int),
+		TotalAsks: make(map[t.Price]int),
+		BidTree:   btree.New(4),
+		AskTree:   btree.New(4),
+
+		Executions: make(map[string]*t.OrderExecution),
+	}
+	sh.DepthOrderIDIndex = make(map[string][]interface{})
+	sh.DepthExecutionSeq = make(map[string]map[t.Price]*utils.Queue[string])
+	sh.DepthExecutionSeq[t.Bids] = make(map[t.Price]*utils.Queue[string])
+	sh.DepthExecutionSeq[t.Asks] = make(map[t.Price]*utils.Queue[string])
+	sh.BidAskOverlapCheck = btree.New(4)
+	sh.Stops = NewStopBook()
+}
+
+// Running 매칭 고루틴 실행 여부
+func (sh *SymbolShard) Running() bool {
+	sh.runLock.RLock()
+	defer sh.runLock.RUnlock()
+	return sh.running
+}
+
+func (sh *SymbolShard) start() {
+	sh.runLock.Lock()
+	defer sh.runLock.Unlock()
+	if sh.running {
+		return
+	}
+	sh.running = true
+	sh.quit = make(chan struct{})
+	sh.done = make(chan struct{})
+	go sh.run(sh.quit, sh.done)
+}
+
+func (sh *SymbolShard) stop() {
+	// 전송 중인 작업이 모두 큐에 들어간 뒤에 종료 (이후 runTask 는 호출한 고루틴에서 바로 실행)
+	sh.runLock.Lock()
+	if !sh.running {
+		sh.runLock.Unlock()
+		return
+	}
+	sh.running = false
+	sh.runLock.Unlock()
+
+	close(sh.quit)
+	<-sh.done // 같은 호가를 두 고루틴이 동시에 건드리지 않도록 종료까지 대기
+}
+
+func (sh *SymbolShard) run(quit, done chan struct{}) {
+	defer close(done)
+
+	// 주기적으로 호가 스냅샷 저장
+	snapshotTicker := time.NewTicker(journalSnapshotInterval())
+	defer snapshotTicker.Stop()
+
+	for {
+		select {
+		case orderReq := <-sh.OrderRequestChan:
+			sh.bookLock.Lock()
+			sh.processOrderRequest(orderReq)
+			sh.bookLock.Unlock()
+		case task := <-sh.tasks:
+			sh.bookLock.Lock()
+			task()
+			sh.bookLock.Unlock()
+		case <-snapshotTicker.C:
+			sh.bookLock.Lock()
+			sh.writeSnapshot()
+			sh.bookLock.Unlock()
+		case <-quit:
+			// 종료 중에 멈춘 샤드로 바로 실행되는 작업과 겹치지 않도록 끝날 때까지 잡고 있음
+			sh.bookLock.Lock()
+			sh.rejectPending()
+			sh.runPendingTasks()
+			sh.writeSnapshot()
+			sh.bookLock.Unlock()
+			return
+		}
+	}
+}
+
+// rejectPending 종료 시점에 큐에 남아있는 주문은 모두 거절 처리
+func (sh *SymbolShard) rejectPending() {
+	for {
+		select {
+		case orderReq := <-sh.OrderRequestChan:
+			rejectOrderRequest(orderReq, 503, "Symbol '"+sh.Symbol+"' is not tradable")
+		default:
+			return
+		}
+	}
+}
+
+// runPendingTasks 종료 시점에 큐에 남아있는 작업 실행 (작업 결과를 기다리는 호출자가 멈추지 않도록)
+func (sh *SymbolShard) runPendingTasks() {
+	for {
+		select {
+		case task := <-sh.tasks:
+			task()
+		default:
+			return
+		}
+	}
+}
+
+// enqueue 주문을 샤드 큐에 넣음, 큐가 가득 찬 경우 다른 심볼을 막지 않도록 바로 거절
+func (sh *SymbolShard) enqueue(orderReq t.OrderRequest) {
+	select {
+	case sh.OrderRequestChan <- orderReq:
+	default:
+		rejectOrderRequest(orderReq, 503, "Order processing is busy, please try again later")
+	}
+}
+
+// runTask 매칭 고루틴 안에서 작업 실행 (고루틴이 멈춰있으면 호가 잠금을 잡고 호출한 고루틴에서 바로 실행)
+func (sh *SymbolShard) runTask(task func()) {
+	sh.runLock.RLock()
+	defer sh.runLock.RUnlock()
+	if !sh.running {
+		sh.bookLock.Lock()
+		defer sh.bookLock.Unlock()
+		task()
+		return
+	}
+	sh.tasks <- task
+}
+
+// getOpenOrders 매칭 고루틴 안에서 사용자의 미체결 주문 목록을 읽어옴
+func (sh *SymbolShard) getOpenOrders(userID int) []t.OpenOrder {
+	result := make(chan []t.OpenOrder, 1)
+	sh.runTask(func() {
+		result <- sh.openOrders(userID)
+	})
+	return <-result
+}
+
+// clearBook 일일 정리 (당일 주문 만료 후 저널 압축, GTC, GTD 주문은 다음 거래일에도 유지)
+func (sh *SymbolShard) clearBook() {
+	sh.expireOrders(time.Now().UnixMilli(), true)
+	sh.writeSnapshot()
+
+	// 호가 캐시가 비워졌으므로 남아있는 호가 다시 브로드캐스트
+	sh.broadcastBook()
+}
+
+// broadcastBook 현재 호가 전체 브로드캐스트
+func (sh *SymbolShard) broadcastBook() {
+	timestamp := time.Now().UnixMilli()
+	for price, quantity := range sh.Depth.TotalBids {
+		broadcastDepth(t.UpdateDepth{Timestamp: timestamp, Symbol: sh.Symbol, Side: t.Bids, Price: price, Quantity: quantity})
+	}
+	for price, quantity := range sh.Depth.TotalAsks {
+		broadcastDepth(t.UpdateDepth{Timestamp: timestamp, Symbol: sh.Symbol, Side: t.Asks, Price: price, Quantity: quantity})
+	}
+}
+
+// snapshot 현재 호가를 가격 우선, 시간 우선 순서로 추출
+func (sh *SymbolShard) snapshot() BookSnapshot {
+	snapshot := BookSnapshot{
+		Timestamp: time.Now().UnixMilli(),
+		Symbol:    sh.Symbol,
+		LastPrice: sh.Depth.LastPrice,
+		Stops:     sh.Stops.values(),
+		Auction:   sh.auction,
+	}
+
+	collect := func(side string, price t.Price) []SnapshotOrder {
+		var orders []SnapshotOrder
+		seq := sh.DepthExecutionSeq[side][price]
+		if seq == nil {
+			return orders
+		}
+		for _, orderID := range seq.Values() {
+			index := sh.DepthOrderIDIndex[orderID]
+			if index == nil {
+				continue
+			}
+			order, _ := lookupOrder(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+			snapshotOrder := SnapshotOrder{
+				OrderID:   orderID,
+				UserID:    index[0].(int),
+				Price:     index[2].(t.Price),
+				Quantity:  index[3].(int),
+				CreatedAt: order.CreatedAt,
+			}
+			if exec, ok := sh.Depth.Executions[orderID]; ok {
+				snapshotOrder.ClientOrderID = exec.ClientOrderID
+				snapshotOrder.OrderQuantity = exec.Quantity
+				snapshotOrder.CumQuantity = exec.CumQuantity
+				snapshotOrder.CumAmount = exec.CumAmount
+				snapshotOrder.TimeInForce = exec.TimeInForce
+				snapshotOrder.ExpireAt = exec.ExpireAt
+				snapshotOrder.PostOnly = exec.PostOnly
+				snapshotOrder.DisplayQuantity = exec.DisplayQuantity
+				snapshotOrder.SelfTradePrevention = exec.SelfTradePrevention
+			}
+			orders = append(orders, snapshotOrder)
+		}
+		return orders
+	}
+
+	sh.Depth.BidTree.Descend(func(i btree.Item) bool {
+		snapshot.Bids = append(snapshot.Bids, collect(t.Bids, t.Price(i.(t.PriceItem)))...)
+		return true
+	})
+	sh.Depth.AskTree.Ascend(func(i btree.Item) bool {
+		snapshot.Asks = append(snapshot.Asks, collect(t.Asks, t.Price(i.(t.PriceItem)))...)
+		return true
+	})
+	return snapshot
+}
+
+// openOrders 사용자의 호가에 남아있는 주문과 발동 대기 중인 스탑 주문 목록 (접수 시각 순, 매칭 고루틴 안에서 호출할 것)
+func (sh *SymbolShard) openOrders(userID int) []t.OpenOrder {
+	orders := make([]t.OpenOrder, 0)
+	for orderID, index := range sh.DepthOrderIDIndex {
+		if index[0].(int) != userID {
+			continue
+		}
+		order, ok := lookupOrder(orderID, &sh.Depth, &sh.DepthOrderIDIndex)
+		if !ok {
+			continue
+		}
+
+		side := t.SideBuy
+		if index[1].(string) == t.Asks {
+			side = t.SideSell
+		}
+		openOrder := t.OpenOrder{
+			OrderID:           orderID,
+			Symbol:            sh.Symbol,
+			Side:              side,
+			OrderType:         t.OrderTypeLimit,
+			Price:             index[2].(t.Price),
+			OriginalQuantity:  order.Quantity,
+			RemainingQuantity: order.Quantity,
+			Status:            t.StatusOpen,
+			CreatedAt:         order.CreatedAt,
+			UpdatedAt:         order.UpdatedAt,
+		}
+		if exec, ok := sh.Depth.Executions[orderID]; ok {
+			openOrder.ClientOrderID = exec.ClientOrderID
+			openOrder.TimeInForce = exec.TimeInForce
+			openOrder.ExpireAt = exec.ExpireAt
+			openOrder.PostOnly = exec.PostOnly
+			openOrder.SelfTradePrevention = exec.SelfTradePrevention
+			if exec.DisplayQuantity > 0 {
+				// 아이스버그 주문은 숨겨진 수량까지 포함
+				openOrder.DisplayQuantity = exec.DisplayQuantity
+				openOrder.RemainingQuantity = exec.Quantity - exec.CumQuantity
+			}
+			openOrder.OriginalQuantity = exec.Quantity
+			if exec.CumQuantity > 0 {
+				openOrder.AvgPrice = float64(exec.CumAmount) / float64(exec.CumQuantity)
+				openOrder.Status = t.StatusPartiallyFilled
+			}
+		}
+		orders = append(orders, openOrder)
+	}
+	for _, stop := range sh.Stops.Orders {
+		if stop.Order.UserID != userID {
+			continue
+		}
+		orders = append(orders, t.OpenOrder{
+			OrderID:             stop.Order.OrderID,
+			ClientOrderID:       stop.Order.ClientOrderID,
+			Symbol:              sh.Symbol,
+			Side:                stop.Order.Side,
+			OrderType:           stop.Order.OrderType,
+			Price:               stop.Order.Price,
+			StopPrice:           stop.Order.StopPrice,
+			TimeInForce:         stop.Order.TimeInForce,
+			ExpireAt:            stop.Order.ExpireAt,
+			SelfTradePrevention: stop.Order.SelfTradePrevention,
+			OriginalQuantity:    stop.Order.Quantity,
+			RemainingQuantity:   stop.Order.Quantity,
+			Status:              t.StatusOpen,
+			CreatedAt:           stop.CreatedAt,
+			UpdatedAt:           stop.UpdatedAt,
+		})
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt < orders[j].CreatedAt
+	})
+	return orders
+}
+
+func (sh *SymbolShard) writeSnapshot() {
+	if sh.journal == nil {
+		return
+	}
+	if err := sh.journal.WriteSnapshot(sh.snapshot()); err != nil {
+		log.Printf("Error writing book snapshot for %s: %v", sh.Symbol, err)
+	}
+}
+
+func rejectOrderRequest(orderReq t.OrderRequest, code int, message string) {
+	sendResult(orderReq.ResultChan, t.Result{
+		Timestamp: time.Now().UnixMilli(),
+		Success:   false,
+		Message:   message,
+		Code:      code,
+	})
+}
+
+// sendResult 요청 결과 반환 (결과를 기다리지 않는 요청은 생략, 결과 채널이 차 있어도 매칭 엔진이 멈추지 않도록 대기하지 않음)
+func sendResult(resultChan chan t.Result, result t.Result) {
+	if resultChan == nil {
+		return
+	}
+	select {
+	case resultChan <- result:
+	default:
+	}
+}
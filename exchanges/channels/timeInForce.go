@@ -0,0 +1,155 @@
+package channels
+
+import (
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// normalizeTimeInForce 신규 주문의 유효 기간 검증 및 기본값 설정 (통과하면 빈 사유 코드 반환)
+func normalizeTimeInForce(orderReq *t.OrderRequest, now int64, checkExpireAt bool) (string, string) {
+	if orderReq.OrderType == t.OrderTypeMarket {
+		// 시장가 주문은 IOC, FOK 만 가능 (time_in_force 가 없으면 기존 market_order_type 사용)
+		if orderReq.TimeInForce == "" {
+			orderReq.TimeInForce = t.TimeInForceIOC
+			if orderReq.MarketOrderType == t.MarketOrderFOK {
+				orderReq.TimeInForce = t.TimeInForceFOK
+			}
+		}
+		if orderReq.TimeInForce != t.TimeInForceIOC && orderReq.TimeInForce != t.TimeInForceFOK {
+			return t.ReasonInvalidTimeInForce, "Market orders only support IOC or FOK"
+		}
+		orderReq.MarketOrderType = orderReq.TimeInForce
+		orderReq.ExpireAt = 0
+		return "", ""
+	}
+
+	if orderReq.TimeInForce == "" {
+		orderReq.TimeInForce = t.TimeInForceDay
+	}
+	switch orderReq.TimeInForce {
+	case t.TimeInForceDay, t.TimeInForceGTC, t.TimeInForceIOC, t.TimeInForceFOK:
+		orderReq.ExpireAt = 0
+	case t.TimeInForceGTD:
+		if orderReq.ExpireAt <= 0 || (checkExpireAt && orderReq.ExpireAt <= now) {
+			return t.ReasonInvalidExpireTime, "ExpireAt must be in the future for GTD orders"
+		}
+	default:
+		return t.ReasonInvalidTimeInForce, "Invalid TimeInForce"
+	}
+	return "", ""
+}
+
+// limitAvailableQuantity 지정가 이내에서 바로 체결 가능한 반대 호가 물량
+func limitAvailableQuantity(orderReq *t.OrderRequest, depth *t.MarketDepth) int {
+	totalAvailable := 0
+	switch orderReq.Side {
+	case t.SideBuy:
+		depth.AskTree.AscendRange(t.PriceItem(0), t.PriceItem(orderReq.Price+1), func(i btree.Item) bool {
+			price := t.Price(i.(t.PriceItem))
+			totalAvailable += depth.TotalAsks[price] - selfTradeQuantity(orderReq, depth.Asks[price])
+			return totalAvailable < orderReq.Quantity
+		})
+	case t.SideSell:
+		depth.BidTree.DescendRange(t.PriceItem(math.MaxInt64), t.PriceItem(orderReq.Price-1), func(i btree.Item) bool {
+			price := t.Price(i.(t.PriceItem))
+			totalAvailable += depth.TotalBids[price] - selfTradeQuantity(orderReq, depth.Bids[price])
+			return totalAvailable < orderReq.Quantity
+		})
+	}
+	return totalAvailable
+}
+
+// cancelUnfilled 호가에 남아있는 지정가 주문의 남은 수량 취소 (IOC, FOK)
+func cancelUnfilled(orderReq *t.OrderRequest, depth *t.MarketDepth, depthIndex *map[string][]interface{}, bidAskOverLab *btree.BTree, executionSeq *map[string]map[t.Price]*utils.Queue[string], reason string) {
+	index := (*depthIndex)[orderReq.OrderID]
+	if index == nil {
+		// 전부 체결된 주문
+		return
+	}
+	remainingQuantity := index[3].(int)
+	processCancel(orderReq, depth, depthIndex, bidAskOverLab, executionSeq)
+
+	timestamp := time.Now().UnixMilli()
+	orderReq.Timestamp = timestamp
+	orderReq.Status = t.StatusCanceled
+	orderReq.Quantity = remainingQuantity
+	update := t.UpdateDepth{
+		Timestamp: timestamp,
+		Symbol:    orderReq.Symbol,
+		Price:     orderReq.Price,
+	}
+	if orderReq.Side == t.SideBuy {
+		update.Side = t.Bids
+		update.Quantity = depth.TotalBids[orderReq.Price]
+	} else {
+		update.Side = t.Asks
+		update.Quantity = depth.TotalAsks[orderReq.Price]
+	}
+	broadcastDepth(update)
+	reportCancel(depth, orderReq, reason)
+}
+
+// expireOrders 유효 기간이 지난 주문을 취소 요청으로 처리 (저널에 기록되므로 복구 시에도 같은 순서로 만료됨)
+// endOfDay 이면 DAY 주문(발동 대기 중인 IOC, FOK 스탑 주문 포함)도 만료
+func (sh *SymbolShard) expireOrders(now int64, endOfDay bool) {
+	var expired []string
+	for orderID, exec := range sh.Depth.Executions {
+		if sh.DepthOrderIDIndex[orderID] == nil && sh.Stops.Orders[orderID] == nil {
+			continue
+		}
+		switch exec.TimeInForce {
+		case t.TimeInForceGTC:
+			continue
+		case t.TimeInForceGTD:
+			if exec.ExpireAt > now {
+				continue
+			}
+		default:
+			if !endOfDay {
+				continue
+			}
+		}
+		expired = append(expired, orderID)
+	}
+	sort.Strings(expired)
+
+	for _, orderID := range expired {
+		exec := sh.Depth.Executions[orderID]
+		timeInForce := exec.TimeInForce
+		if timeInForce == "" {
+			timeInForce = t.TimeInForceDay
+		}
+		sh.processOrderRequest(t.OrderRequest{
+			UserID:       exec.UserID,
+			OrderID:      orderID,
+			Symbol:       sh.Symbol,
+			Status:       t.StatusCanceled,
+			Side:         exec.Side,
+			CancelReason: "Order expired (" + timeInForce + ")",
+			ResultChan:   make(chan t.Result, 1),
+		})
+	}
+	if len(expired) > 0 {
+		log.Printf("Expired %d orders for %s", len(expired), sh.Symbol)
+	}
+}
+
+// ExpireOrders 모든 심볼에서 유효 기간이 지난 주문 만료 (endOfDay 이면 DAY 주문도 만료)
+func (po *ProcessOrders) ExpireOrders(endOfDay bool) {
+	po.lock.RLock()
+	defer po.lock.RUnlock()
+
+	now := time.Now().UnixMilli()
+	for _, sh := range po.shards {
+		sh := sh
+		sh.runTask(func() {
+			sh.expireOrders(now, endOfDay)
+		})
+	}
+}
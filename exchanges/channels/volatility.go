@@ -0,0 +1,149 @@
+package channels
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/exchanges"
+	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/btree"
+)
+
+var (
+	cooldownLock    sync.Mutex
+	pendingCooldown = make(map[string]bool) // DB 반영 대기 중인 심볼별 cooldown 태그
+	cooldownSignal  = make(chan struct{}, 1)
+	cooldownOnce    sync.Once
+)
+
+// volatilityInterruptionDuration 변동성 완화장치 발동 후 단일가 매매 시간
+func volatilityInterruptionDuration() time.Duration {
+	sec, err := strconv.Atoi(utils.GetEnv("VOLATILITY_INTERRUPTION_SECONDS", "120"))
+	if err != nil || sec <= 0 {
+		sec = 120
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// outsideBand 체결 가능 범위를 벗어난 가격이면 체결 중단 표시 (범위가 없으면 항상 false)
+func outsideBand(depth *t.MarketDepth, price t.Price) bool {
+	if depth.PriceBand[1] == 0 {
+		return false
+	}
+	if price < depth.PriceBand[0] || price > depth.PriceBand[1] {
+		depth.Interrupted = true
+		return true
+	}
+	return false
+}
+
+// armPriceBand 직전 체결가 기준으로 체결 가능 범위 설정 (주문 체결 전에 호출)
+func (sh *SymbolShard) armPriceBand() {
+	sh.Depth.Interrupted = false
+	sh.Depth.PriceBand = [2]t.Price{}
+	if sh.params.VolatilityBand <= 0 || sh.Depth.LastPrice <= 0 {
+		return
+	}
+	band := t.Price(float64(sh.Depth.LastPrice) * sh.params.VolatilityBand / 100)
+	sh.Depth.PriceBand = [2]t.Price{sh.Depth.LastPrice - band, sh.Depth.LastPrice + band}
+}
+
+// matchOrder 체결 가능 범위 안에서 주문 체결, 범위를 벗어나는 체결은 중단하고 변동성 완화장치 발동
+func (sh *SymbolShard) matchOrder(orderReq *t.OrderRequest) {
+	sh.armPriceBand()
+	processOrder(orderReq, &sh.Depth, &sh.DepthOrderIDIndex, sh.BidAskOverlapCheck, &sh.DepthExecutionSeq, sh.params.ReferencePrice)
+
+	interrupted := sh.Depth.Interrupted
+	sh.Depth.Interrupted = false
+	sh.Depth.PriceBand = [2]t.Price{}
+	if interrupted {
+		sh.startVolatilityInterruption()
+	}
+}
+
+// startVolatilityInterruption 일정 시간 동안 접속 매매를 멈추고 단일가 매매로 전환 (cooldown 태그 설정)
+func (sh *SymbolShard) startVolatilityInterruption() {
+	if sh.auction != "" {
+		return
+	}
+	duration := volatilityInterruptionDuration()
+	sh.volatilityUntil = time.Now().Add(duration).UnixMilli()
+	sh.setAuction(t.AuctionVolatility)
+	log.Printf("Volatility interruption for %s (last price %d)", sh.Symbol, sh.Depth.LastPrice)
+
+	// 시간이 지나면 현재 세션으로 복귀 (단일가 체결 후 접속 매매 재개)
+	time.AfterFunc(duration, func() {
+		sh.runTask(func() {
+			sh.syncSession(exchanges.MarketStatus)
+		})
+	})
+}
+
+// setCooldown 변동성 완화장치 발동 여부를 심볼 태그에 반영 (symbol.IsTradable 에서 취소 외 주문 접수 차단)
+// DB 쓰기는 매칭 고루틴을 막지 않도록 cooldownWriter 에서 처리
+func (sh *SymbolShard) setCooldown(cooldown bool) {
+	if isReplaying(sh.Symbol) {
+		return
+	}
+	cooldownLock.Lock()
+	pendingCooldown[sh.Symbol] = cooldown
+	cooldownLock.Unlock()
+
+	cooldownOnce.Do(func() {
+		go cooldownWriter()
+	})
+	select {
+	case cooldownSignal <- struct{}{}:
+	default:
+	}
+}
+
+// cooldownWriter 반영 대기 중인 cooldown 태그를 DB에 기록 (심볼별 마지막 상태만 기록하므로 순서가 뒤바뀌지 않음)
+func cooldownWriter() {
+	for range cooldownSignal {
+		cooldownLock.Lock()
+		pending := pendingCooldown
+		pendingCooldown = make(map[string]bool)
+		cooldownLock.Unlock()
+
+		failed := false
+		for symbol, cooldown := range pending {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := postgresApp.Get().SymbolRepo().SetTag(ctx, symbol, "cooldown", cooldown)
+			cancel()
+			if err != nil {
+				log.Printf("Error updating cooldown tag for %s: %v", symbol, err)
+				// 그 사이 새 상태가 들어오지 않았으면 다시 시도 (해제가 반영되지 않으면 주문 접수가 계속 막힘)
+				cooldownLock.Lock()
+				if _, ok := pendingCooldown[symbol]; !ok {
+					pendingCooldown[symbol] = cooldown
+				}
+				cooldownLock.Unlock()
+				failed = true
+			}
+		}
+		if failed {
+			time.AfterFunc(time.Second, func() {
+				select {
+				case cooldownSignal <- struct{}{}:
+				default:
+				}
+			})
+		}
+	}
+}
+
+// bandLimited 체결 가능 범위를 벗어난 가격대를 만나면 순회 중단
+func bandLimited(depth *t.MarketDepth, iterator btree.ItemIterator) btree.ItemIterator {
+	return func(i btree.Item) bool {
+		if outsideBand(depth, t.Price(i.(t.PriceItem))) {
+			return false
+		}
+		return iterator(i)
+	}
+}
@@ -6,14 +6,11 @@ import (
 	"PJS_Exchange/routes/ws"
 	"PJS_Exchange/template"
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"sync"
 	"time"
-
-	"github.com/gofiber/websocket/v2"
 )
 
 type Job struct {
@@ -30,6 +27,10 @@ func processJob(job Job) error {
 		return processClearExpiredAPIKeys()
 	case "clear_redis_cache":
 		return processClearRedisCache()
+	case "expire_orders":
+		return processExpireOrders()
+	case "circuit_breaker":
+		return processCircuitBreaker()
 	}
 	return nil
 }
@@ -44,6 +45,9 @@ func processGetSession() error {
 		return fmt.Errorf("UpdateMarketStatus error: %v", err)
 	}
 
+	// 세션에 맞춰 단일가 매매 시작 / 종료 (장 종료 시 DAY 주문 만료보다 먼저 종가 단일가 체결)
+	OP.SyncSession(exchanges.MarketStatus)
+
 	// 프리장 시작 30분 전, 5분 전, 1분 전 알림
 	sessionTime := exchanges.GetChangeSessionTime()
 	if sessionTime == nil {
@@ -56,50 +60,38 @@ func processGetSession() error {
 	nowTime, _ := time.Parse("15:04", time.Now().Format("15:04"))
 
 	if nowTime.Equal(preT) {
-		sender, err := json.Marshal(template.SessionStatus{
+		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), ws.SessionPayload(template.SessionStatus{
 			Session: "pre-30m",
-		})
-		if err != nil {
-			return fmt.Errorf("failed to marshal session status: %v", err)
-		}
-		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), websocket.TextMessage, sender)
+		}))
 	} else if nowTime.Equal(preF) {
-		sender, err := json.Marshal(template.SessionStatus{
+		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), ws.SessionPayload(template.SessionStatus{
 			Session: "pre-5m",
-		})
-		if err != nil {
-			return fmt.Errorf("failed to marshal session status: %v", err)
-		}
-		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), websocket.TextMessage, sender)
+		}))
 	} else if nowTime.Equal(preO) {
-		sender, err := json.Marshal(template.SessionStatus{
+		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), ws.SessionPayload(template.SessionStatus{
 			Session: "pre-1m",
-		})
-		if err != nil {
-			return fmt.Errorf("failed to marshal session status: %v", err)
-		}
-		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), websocket.TextMessage, sender)
+		}))
 	}
 
 	if previousStatus != exchanges.MarketStatus {
 		// 세션 상태가 변경된 경우에만 알림 전송
 		//log.Printf("Market status changed from %s to %s", previousStatus, MarketStatus)
-		sender, err := json.Marshal(template.SessionStatus{
+		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), ws.SessionPayload(template.SessionStatus{
 			Session: exchanges.MarketStatus,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to marshal session status: %v", err)
-		}
-		ws.SessionHub.BroadcastMessage(time.Now().UnixMilli(), websocket.TextMessage, sender)
+		}))
 	}
 
 	// 장 종료 10분 후 모든 클라이언트 연결 종료 처리 (세션 WS 제외)
 	// 이전 상태가 "post"였고 현재 상태가 "closed"인 경우
 	if previousStatus == "post" && exchanges.MarketStatus == "closed" {
+		// 장 종료 시 DAY 주문 만료
+		OP.ExpireOrders(true)
+
 		time.AfterFunc(10*time.Minute, func() {
 			ws.DepthHub.DisconnectAll()
 			ws.LedgerHub.DisconnectAll()
 			ws.NotifyHub.DisconnectAll()
+			ws.TradeHub.DisconnectAll()
 		})
 	}
 	return nil
@@ -110,6 +102,19 @@ func processClearExpiredAPIKeys() error {
 	return postgresApp.Get().APIKeyRepo().CleanupExpiredKeys(ctx)
 }
 
+// processExpireOrders 만료 시각이 지난 GTD 주문 만료
+func processExpireOrders() error {
+	OP.ExpireOrders(false)
+	return nil
+}
+
+// processCircuitBreaker 거래 재개 알림, 시장 급락 시 서킷 브레이커 자동 발동
+func processCircuitBreaker() error {
+	notifyResume()
+	OP.CheckCircuitBreaker()
+	return nil
+}
+
 func processClearRedisCache() error {
 	// 프리장 시작 30분 전에 Redis 캐시 비우기
 	sessionTime := exchanges.GetChangeSessionTime()
@@ -122,8 +127,11 @@ func processClearRedisCache() error {
 	if exchanges.MarketStatus == "closed" && nowTime.Equal(preOpen.Add(-30*time.Minute)) {
 		// TODO: Redis 캐시 비우기
 		ws.ClearTempDepthData()
+		OP.ClearDepth()
+		OP.ClearClientOrders()
 		ws.ClearTempLedgerData()
 		ws.ClearTempNotifyData()
+		ws.ClearTempTradeData()
 		log.Println("Redis 캐시가 비워졌습니다.")
 		return nil
 	}
@@ -190,7 +198,7 @@ func scheduler(jobChan chan<- Job, wg *sync.WaitGroup) {
 func createAndSendJob(jobChan chan<- Job, jobID *int) bool {
 	currentTime := time.Now().Format("15:04:05")
 
-	jobTypes := []string{"get_session", "clear_expired_api_keys", "clear_redis_cache"}
+	jobTypes := []string{"get_session", "clear_expired_api_keys", "clear_redis_cache", "expire_orders", "circuit_breaker"}
 
 	for _, jobType := range jobTypes {
 		job := Job{
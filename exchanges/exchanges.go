@@ -3,7 +3,9 @@ package exchanges
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -11,6 +13,7 @@ var (
 	MarketStatus   string
 	cachedExchange *ExchangeType
 	lastModTime    time.Time
+	loadLock       sync.Mutex
 )
 
 type ExchangeType struct {
@@ -18,6 +21,7 @@ type ExchangeType struct {
 	ShortName              string             `json:"short_name"`
 	Country                string             `json:"country"`
 	DefaultCurrency        string             `json:"default_currency"`
+	PricePrecision         int                `json:"price_precision"` // 가격 소수점 자리수 (기본 통화에서 계산, 예: KRW 0, USD 2)
 	DefaultUTCOffset       int                `json:"default_utc_offset"`
 	DefaultTimezone        string             `json:"default_timezone"`
 	AvailableTypes         []string           `json:"available_types"`
@@ -44,6 +48,9 @@ type Anniversary struct {
 }
 
 func Load() (*ExchangeType, error) {
+	loadLock.Lock()
+	defer loadLock.Unlock()
+
 	info, err := os.Stat("./exchanges/PJSe.json")
 	if err != nil {
 		return nil, err
@@ -62,6 +69,13 @@ func Load() (*ExchangeType, error) {
 			return nil, err
 		}
 
+		// 기본 통화가 바뀌어도 호가가 열려 있는 동안에는 가격 소수점 자리수를 바꾸지 않음 (재시작 후 적용)
+		precision := currencyPrecision(exchange.DefaultCurrency)
+		exchange.PricePrecision = fixPricePrecision(precision)
+		if exchange.PricePrecision != precision {
+			log.Printf("Price precision change to %d (%s) ignored until restart", precision, exchange.DefaultCurrency)
+		}
+
 		cachedExchange = &exchange
 		lastModTime = info.ModTime()
 	}
@@ -0,0 +1,46 @@
+package exchanges
+
+import "sync"
+
+// TradingHalt 거래소 전체 거래 중단 (서킷 브레이커) 상태
+type TradingHalt struct {
+	Halted   bool   `json:"halted"`
+	Reason   string `json:"reason,omitempty"`
+	Source   string `json:"source,omitempty"`    // "admin": 관리자 발동, "auto": 시장 급락으로 자동 발동
+	HaltedAt int64  `json:"halted_at,omitempty"` // 발동 시각 (밀리초)
+	ResumeAt int64  `json:"resume_at,omitempty"` // 재개 예정 시각 (밀리초, 0이면 관리자가 재개할 때까지 유지)
+}
+
+var (
+	halt     TradingHalt
+	haltLock sync.RWMutex
+)
+
+// GetHalt 현재 거래 중단 상태 반환
+func GetHalt() TradingHalt {
+	haltLock.RLock()
+	defer haltLock.RUnlock()
+	return halt
+}
+
+// IsHalted 거래 중단 중인지 확인
+func IsHalted() bool {
+	haltLock.RLock()
+	defer haltLock.RUnlock()
+	return halt.Halted
+}
+
+// SetHalt 거래 중단 상태 설정 (재시작 후 복구는 channels 에서 상태 파일로 처리)
+func SetHalt(h TradingHalt) {
+	haltLock.Lock()
+	defer haltLock.Unlock()
+	h.Halted = true
+	halt = h
+}
+
+// ClearHalt 거래 중단 해제
+func ClearHalt() {
+	haltLock.Lock()
+	defer haltLock.Unlock()
+	halt = TradingHalt{}
+}
@@ -0,0 +1,66 @@
+package exchanges
+
+import (
+	t "PJS_Exchange/template"
+	"math"
+	"strings"
+	"sync/atomic"
+)
+
+// currencyPrecisions 통화별 최소 단위 소수점 자리수 (ISO 4217, 목록에 없으면 2자리)
+var currencyPrecisions = map[string]int{
+	"KRW": 0,
+	"JPY": 0,
+	"VND": 0,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CNY": 2,
+	"HKD": 2,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+// 처음 불러온 가격 소수점 자리수 (가격은 이 자리수의 정수로 호가, 저널, DB에 저장되므로 서버 실행 중에는 바꾸지 않음)
+var (
+	fixedPrecision atomic.Int32
+	precisionFixed atomic.Bool
+)
+
+func currencyPrecision(currency string) int {
+	if precision, ok := currencyPrecisions[strings.ToUpper(currency)]; ok {
+		return precision
+	}
+	return 2
+}
+
+// fixPricePrecision 가격 소수점 자리수 고정 (이미 고정되어 있으면 고정된 값 반환, Load 에서 loadLock 을 잡고 호출)
+func fixPricePrecision(precision int) int {
+	if precisionFixed.Load() {
+		return int(fixedPrecision.Load())
+	}
+	fixedPrecision.Store(int32(precision))
+	precisionFixed.Store(true)
+	return precision
+}
+
+// PricePrecision 거래소 기본 통화의 가격 소수점 자리수 (처음 불러온 뒤에는 파일을 다시 읽지 않음)
+func PricePrecision() int {
+	if !precisionFixed.Load() {
+		if _, err := Load(); err != nil {
+			return 2
+		}
+	}
+	return int(fixedPrecision.Load())
+}
+
+// ToPrice 통화 단위 가격을 고정 소수점 가격으로 변환 (예: USD 12.34 -> 1234)
+func ToPrice(value float64) t.Price {
+	return t.Price(math.Round(value * math.Pow10(PricePrecision())))
+}
+
+// IsPriceRepresentable 통화 단위 가격이 최소 단위로 나누어 떨어지는지 확인 (예: USD 0.001 -> false)
+func IsPriceRepresentable(value float64) bool {
+	scaled := value * math.Pow10(PricePrecision())
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
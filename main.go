@@ -6,7 +6,11 @@ import (
 	"PJS_Exchange/exchanges"
 	"PJS_Exchange/exchanges/channels"
 	router "PJS_Exchange/routes"
+	"PJS_Exchange/routes/fix"
+	"PJS_Exchange/routes/rpc"
+	"PJS_Exchange/routes/ws"
 	"PJS_Exchange/sys"
+	"PJS_Exchange/template"
 	"PJS_Exchange/utils"
 	"context"
 	"errors"
@@ -34,12 +38,33 @@ func main() {
 	st := postgresApp.Get()
 	defer st.Close()
 
+	// 체결 내역 / 주문 이벤트 저장 시스템 초기화
+	tw := channels.NewBatchWriter("trades", st.TradeRepo().InsertTrades)
+	go tw.Create()
+	defer tw.Destroy()
+	channels.TW = tw
+
+	ew := channels.NewBatchWriter("order events", st.OrderEventRepo().InsertOrderEvents)
+	go ew.Create()
+	defer ew.Destroy()
+	channels.EW = ew
+
 	// 거래 처리 시스템 초기화
 	exo := channels.NewProcessOrders()
 	go exo.Create()
 	defer exo.Destroy()
 	channels.OP = exo
 
+	// 알림 연결 종료 시 주문 취소 (cancel-on-disconnect)
+	ws.SetCancelOnDisconnectHandler(func(userID int) int {
+		return len(exo.CancelOrders("", userID, "", "Canceled on disconnect"))
+	})
+
+	// 거래 WebSocket 주문 접수
+	ws.SetOrderSubmitter(func(ctx context.Context, item template.BatchOrderItem, user *postgresql.User, apiKey *postgresql.APIKey) (*template.OrderRequest, template.Result, bool) {
+		return exo.SubmitItem(ctx, item, user, apiKey, nil)
+	})
+
 	// Redis 초기화
 	//redisClient := databases.NewRedisClient()
 	//defer func(redisClient *databases.RedisClient) {
@@ -112,6 +137,24 @@ func main() {
 	router.SetupAPIRoutes(sv)
 	router.SetupWebSocketRoutes(sv)
 
+	// FIX 4.4 주문 접수 게이트웨이 (FIX_PORT 를 설정한 경우만)
+	if fix.Enabled() {
+		go func() {
+			if err := fix.Start(); err != nil {
+				log.Errorf("FIX gateway stopped: %v", err)
+			}
+		}()
+	}
+
+	// gRPC API (GRPC_PORT 를 설정한 경우만)
+	if rpc.Enabled() {
+		go func() {
+			if err := rpc.Start(); err != nil {
+				log.Errorf("gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
 	log.Fatal(sv.Listen(":" + utils.GetEnv("PORT", "4000")))
 }
 
@@ -0,0 +1,119 @@
+package idempotency
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	keyTTL       = 24 * time.Hour
+)
+
+// response 처음 처리한 요청의 응답 (처리 중이면 done 이 false)
+type response struct {
+	fingerprint string
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	createdAt   time.Time
+}
+
+var (
+	responses = make(map[string]*response) // 사용자 ID + Idempotency-Key 별 응답
+	lock      sync.Mutex
+	lastSweep time.Time
+)
+
+// sweep 보관 기간이 지난 응답 정리 (lock 을 잡은 상태에서 호출할 것)
+func sweep(now time.Time) {
+	if now.Sub(lastSweep) < time.Minute {
+		return
+	}
+	lastSweep = now
+	for key, res := range responses {
+		if now.Sub(res.createdAt) > keyTTL {
+			delete(responses, key)
+		}
+	}
+}
+
+// New Idempotency-Key 헤더가 있는 요청은 응답을 24시간 보관하고, 같은 키로 다시 요청하면 처리하지 않고 처음 응답을 그대로 반환
+// 키는 사용자별로 구분하며 (인증 미들웨어 뒤에 둘 것), 같은 키로 다른 요청을 보내면 422, 처음 요청이 아직 처리 중이면 409 반환
+// 5xx 응답은 보관하지 않으므로 같은 키로 다시 시도할 수 있음 (주문이 매칭 엔진에 전달된 뒤에는 5xx 대신 202 로 응답해야 함)
+func New() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		idempotencyKey := c.Get(HeaderKey)
+		if idempotencyKey == "" {
+			return c.Next()
+		}
+		if len(idempotencyKey) > maxKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Idempotency-Key must be at most " + strconv.Itoa(maxKeyLength) + " characters",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+
+		user, ok := c.Locals("user").(*postgresql.User)
+		if !ok {
+			return c.Next()
+		}
+		key := strconv.Itoa(user.ID) + ":" + idempotencyKey
+
+		sum := sha256.Sum256(append([]byte(c.Method()+" "+c.Path()+"\n"), c.Body()...))
+		fingerprint := hex.EncodeToString(sum[:])
+
+		now := time.Now()
+		lock.Lock()
+		sweep(now)
+		if res, ok := responses[key]; ok && now.Sub(res.createdAt) <= keyTTL {
+			lock.Unlock()
+			switch {
+			case res.fingerprint != fingerprint:
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": "Idempotency-Key was already used for a different request",
+					"code":  fiber.StatusUnprocessableEntity,
+				})
+			case !res.done:
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "A request with this Idempotency-Key is still being processed",
+					"code":  fiber.StatusConflict,
+				})
+			}
+			c.Set(HeaderReplayed, "true")
+			if res.contentType != "" {
+				c.Set(fiber.HeaderContentType, res.contentType)
+			}
+			return c.Status(res.status).Send(res.body)
+		}
+		res := &response{fingerprint: fingerprint, createdAt: now}
+		responses[key] = res
+		lock.Unlock()
+
+		err := c.Next()
+
+		// 에러 핸들러로 넘어가는 경우는 보관하지 않음
+		status := c.Response().StatusCode()
+		lock.Lock()
+		defer lock.Unlock()
+		if err != nil || status >= fiber.StatusInternalServerError {
+			delete(responses, key)
+			return err
+		}
+		res.done = true
+		res.status = status
+		res.contentType = string(c.Response().Header.ContentType())
+		res.body = append([]byte(nil), c.Response().Body()...)
+		return nil
+	}
+}
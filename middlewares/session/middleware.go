@@ -2,6 +2,7 @@ package session
 
 import (
 	"PJS_Exchange/exchanges"
+	"PJS_Exchange/template"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -17,6 +18,16 @@ func IsOnline() fiber.Handler {
 			})
 		}
 
+		// 거래 중단 (서킷 브레이커) 중에는 취소만 가능
+		if halt := exchanges.GetHalt(); halt.Halted && c.Method() != fiber.MethodDelete {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error":       "Trading is halted: " + halt.Reason,
+				"code":        fiber.StatusServiceUnavailable,
+				"reason_code": template.ReasonMarketHalted,
+				"resume_at":   halt.ResumeAt,
+			})
+		}
+
 		//if !userTradableSession[exchanges.MarketStatus] {
 		//	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 		//		"error": "Trading not allowed in the current market session",
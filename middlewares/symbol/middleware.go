@@ -3,6 +3,7 @@ package symbol
 import (
 	"PJS_Exchange/app/postgresApp"
 	"PJS_Exchange/databases/postgresql"
+	"context"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -80,47 +81,16 @@ func IsTradable() fiber.Handler {
 			})
 		}
 
-		symbolData, err := postgresApp.Get().SymbolRepo().GetSymbolData(c.Context(), symbol)
-		if err != nil || symbolData.Status.Status == "" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Symbol '" + symbol + "' is not listed.",
-				"code":  fiber.StatusNotFound,
-			})
-		}
-
-		switch symbolData.Status.Status {
-		case postgresql.StatusActive:
-		case postgresql.StatusSuspended:
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":  "Symbol '" + symbol + "' is suspended.",
-				"reason": symbolData.Status.Reason,
-				"code":   fiber.StatusForbidden,
-			})
-		case postgresql.StatusDelisted:
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error":  "Symbol '" + symbol + "' is not listed.",
-				"reason": symbolData.Status.Reason,
-				"code":   fiber.StatusForbidden,
-			})
-		case postgresql.StatusInactive:
-			// 심볼 정보 조회는 가능하지만, 호가 및 체결 조회는 불가능
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Symbol '" + symbol + "' is inactive.",
-				"code":  fiber.StatusForbidden,
-			})
-		case postgresql.StatusInit:
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Symbol '" + symbol + "' is not listed.",
-				"code":  fiber.StatusForbidden,
-			})
-		}
-
-		// tag에 cooldown이 있는지 확인
-		if symbolData.Tags["cooldown"] {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "Symbol '" + symbol + "' is in cooldown period.",
-				"code":  fiber.StatusForbidden,
-			})
+		symbolData, code, message, reason := CheckTradable(c.Context(), symbol, c.Method() == fiber.MethodDelete)
+		if code != 0 {
+			response := fiber.Map{
+				"error": message,
+				"code":  code,
+			}
+			if reason != "" {
+				response["reason"] = reason
+			}
+			return c.Status(code).JSON(response)
 		}
 
 		//c.Locals("executable", symbol_data.Status.Status == postgresql.StatusActive)
@@ -128,3 +98,31 @@ func IsTradable() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// CheckTradable 심볼이 주문 가능한 상태인지 확인 (주문 불가능하면 응답 코드, 에러 메시지, 사유 반환)
+// 취소 요청(cancel)은 변동성 완화장치 발동 중(단일가 매매)에도 허용
+func CheckTradable(ctx context.Context, symbol string, cancel bool) (*postgresql.Symbol, int, string, string) {
+	symbolData, err := postgresApp.Get().SymbolRepo().GetSymbolData(ctx, symbol)
+	if err != nil || symbolData.Status.Status == "" {
+		return nil, fiber.StatusNotFound, "Symbol '" + symbol + "' is not listed.", ""
+	}
+
+	switch symbolData.Status.Status {
+	case postgresql.StatusActive:
+	case postgresql.StatusSuspended:
+		return nil, fiber.StatusForbidden, "Symbol '" + symbol + "' is suspended.", symbolData.Status.Reason
+	case postgresql.StatusDelisted:
+		return nil, fiber.StatusForbidden, "Symbol '" + symbol + "' is not listed.", symbolData.Status.Reason
+	case postgresql.StatusInactive:
+		// 심볼 정보 조회는 가능하지만, 호가 및 체결 조회는 불가능
+		return nil, fiber.StatusForbidden, "Symbol '" + symbol + "' is inactive.", ""
+	case postgresql.StatusInit:
+		return nil, fiber.StatusForbidden, "Symbol '" + symbol + "' is not listed.", ""
+	}
+
+	// tag에 cooldown이 있는지 확인
+	if symbolData.Tags["cooldown"] && !cancel {
+		return nil, fiber.StatusForbidden, "Symbol '" + symbol + "' is in cooldown period.", ""
+	}
+	return symbolData, 0, "", ""
+}
@@ -0,0 +1,313 @@
+// proto3 문법을 사용한다고 명시합니다.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: protobuf/candles.proto
+
+package candles
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 실시간 시세 데이터
+type MarketData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`        // 종목 코드
+	Price         int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`         // 현재가 (고정 소수점, 거래소 price_precision 기준)
+	Volume        int64                  `protobuf:"varint,3,opt,name=volume,proto3" json:"volume,omitempty"`       // 거래량
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 타임스탬프 (Unix nano)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketData) Reset() {
+	*x = MarketData{}
+	mi := &file_protobuf_candles_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketData) ProtoMessage() {}
+
+func (x *MarketData) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_candles_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketData.ProtoReflect.Descriptor instead.
+func (*MarketData) Descriptor() ([]byte, []int) {
+	return file_protobuf_candles_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MarketData) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MarketData) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *MarketData) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *MarketData) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 봉 데이터 (OHLCV)
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 봉 시작 시각 (Unix milli)
+	Open          int64                  `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`           // 시가 (고정 소수점, 거래소 price_precision 기준)
+	High          int64                  `protobuf:"varint,3,opt,name=high,proto3" json:"high,omitempty"`           // 고가
+	Low           int64                  `protobuf:"varint,4,opt,name=low,proto3" json:"low,omitempty"`             // 저가
+	Close         int64                  `protobuf:"varint,5,opt,name=close,proto3" json:"close,omitempty"`         // 종가
+	Volume        int64                  `protobuf:"varint,6,opt,name=volume,proto3" json:"volume,omitempty"`       // 거래량
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_protobuf_candles_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_candles_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_protobuf_candles_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Candle) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Candle) GetOpen() int64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() int64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() int64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() int64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+// 봉 데이터 목록
+type Candles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`     // 종목 코드
+	Interval      string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"` // 봉 간격 (1m, 5m, 15m, 1h, 1d)
+	Candles       []*Candle              `protobuf:"bytes,3,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candles) Reset() {
+	*x = Candles{}
+	mi := &file_protobuf_candles_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candles) ProtoMessage() {}
+
+func (x *Candles) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_candles_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candles.ProtoReflect.Descriptor instead.
+func (*Candles) Descriptor() ([]byte, []int) {
+	return file_protobuf_candles_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Candles) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Candles) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Candles) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+var File_protobuf_candles_proto protoreflect.FileDescriptor
+
+const file_protobuf_candles_proto_rawDesc = "" +
+	"\n" +
+	"\x16protobuf/candles.proto\"p\n" +
+	"\n" +
+	"MarketData\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x03R\x05price\x12\x16\n" +
+	"\x06volume\x18\x03 \x01(\x03R\x06volume\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\x8e\x01\n" +
+	"\x06Candle\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x03R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x03R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x03R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x03R\x05close\x12\x16\n" +
+	"\x06volume\x18\x06 \x01(\x03R\x06volume\"`\n" +
+	"\aCandles\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12!\n" +
+	"\acandles\x18\x03 \x03(\v2\a.CandleR\acandlesB\x1fZ\x1dPJS_Exchange/protobuf/candlesb\x06proto3"
+
+var (
+	file_protobuf_candles_proto_rawDescOnce sync.Once
+	file_protobuf_candles_proto_rawDescData []byte
+)
+
+func file_protobuf_candles_proto_rawDescGZIP() []byte {
+	file_protobuf_candles_proto_rawDescOnce.Do(func() {
+		file_protobuf_candles_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protobuf_candles_proto_rawDesc), len(file_protobuf_candles_proto_rawDesc)))
+	})
+	return file_protobuf_candles_proto_rawDescData
+}
+
+var file_protobuf_candles_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protobuf_candles_proto_goTypes = []any{
+	(*MarketData)(nil), // 0: MarketData
+	(*Candle)(nil),     // 1: Candle
+	(*Candles)(nil),    // 2: Candles
+}
+var file_protobuf_candles_proto_depIdxs = []int32{
+	1, // 0: Candles.candles:type_name -> Candle
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protobuf_candles_proto_init() }
+func file_protobuf_candles_proto_init() {
+	if File_protobuf_candles_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protobuf_candles_proto_rawDesc), len(file_protobuf_candles_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protobuf_candles_proto_goTypes,
+		DependencyIndexes: file_protobuf_candles_proto_depIdxs,
+		MessageInfos:      file_protobuf_candles_proto_msgTypes,
+	}.Build()
+	File_protobuf_candles_proto = out.File
+	file_protobuf_candles_proto_goTypes = nil
+	file_protobuf_candles_proto_depIdxs = nil
+}
@@ -0,0 +1,100 @@
+// proto3 문법을 사용한다고 명시합니다.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: protobuf/exchange.proto
+
+package exchange
+
+import (
+	market "PJS_Exchange/protobuf/market"
+	orders "PJS_Exchange/protobuf/orders"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_protobuf_exchange_proto protoreflect.FileDescriptor
+
+const file_protobuf_exchange_proto_rawDesc = "" +
+	"\n" +
+	"\x17protobuf/exchange.proto\x12\x04pjse\x1a\x15protobuf/orders.proto\x1a\x15protobuf/market.proto2\xe8\x04\n" +
+	"\bExchange\x12J\n" +
+	"\vCreateOrder\x12\x1f.pjse.orders.CreateOrderRequest\x1a\x1a.pjse.orders.OrderResponse\x12J\n" +
+	"\vModifyOrder\x12\x1f.pjse.orders.ModifyOrderRequest\x1a\x1a.pjse.orders.OrderResponse\x12J\n" +
+	"\vCancelOrder\x12\x1f.pjse.orders.CancelOrderRequest\x1a\x1a.pjse.orders.OrderResponse\x12H\n" +
+	"\rGetOpenOrders\x12\x1e.pjse.orders.OpenOrdersRequest\x1a\x17.pjse.orders.OpenOrders\x12T\n" +
+	"\x16StreamExecutionReports\x12\x1a.pjse.market.StreamRequest\x1a\x1c.pjse.orders.ExecutionReport0\x01\x12E\n" +
+	"\vStreamDepth\x12\x1a.pjse.market.StreamRequest\x1a\x18.pjse.market.DepthUpdate0\x01\x12@\n" +
+	"\fStreamTrades\x12\x1a.pjse.market.StreamRequest\x1a\x12.pjse.market.Trade0\x01\x12O\n" +
+	"\x13StreamSessionStatus\x12\x1a.pjse.market.StreamRequest\x1a\x1a.pjse.market.SessionStatus0\x01B Z\x1ePJS_Exchange/protobuf/exchangeb\x06proto3"
+
+var file_protobuf_exchange_proto_goTypes = []any{
+	(*orders.CreateOrderRequest)(nil), // 0: pjse.orders.CreateOrderRequest
+	(*orders.ModifyOrderRequest)(nil), // 1: pjse.orders.ModifyOrderRequest
+	(*orders.CancelOrderRequest)(nil), // 2: pjse.orders.CancelOrderRequest
+	(*orders.OpenOrdersRequest)(nil),  // 3: pjse.orders.OpenOrdersRequest
+	(*market.StreamRequest)(nil),      // 4: pjse.market.StreamRequest
+	(*orders.OrderResponse)(nil),      // 5: pjse.orders.OrderResponse
+	(*orders.OpenOrders)(nil),         // 6: pjse.orders.OpenOrders
+	(*orders.ExecutionReport)(nil),    // 7: pjse.orders.ExecutionReport
+	(*market.DepthUpdate)(nil),        // 8: pjse.market.DepthUpdate
+	(*market.Trade)(nil),              // 9: pjse.market.Trade
+	(*market.SessionStatus)(nil),      // 10: pjse.market.SessionStatus
+}
+var file_protobuf_exchange_proto_depIdxs = []int32{
+	0,  // 0: pjse.Exchange.CreateOrder:input_type -> pjse.orders.CreateOrderRequest
+	1,  // 1: pjse.Exchange.ModifyOrder:input_type -> pjse.orders.ModifyOrderRequest
+	2,  // 2: pjse.Exchange.CancelOrder:input_type -> pjse.orders.CancelOrderRequest
+	3,  // 3: pjse.Exchange.GetOpenOrders:input_type -> pjse.orders.OpenOrdersRequest
+	4,  // 4: pjse.Exchange.StreamExecutionReports:input_type -> pjse.market.StreamRequest
+	4,  // 5: pjse.Exchange.StreamDepth:input_type -> pjse.market.StreamRequest
+	4,  // 6: pjse.Exchange.StreamTrades:input_type -> pjse.market.StreamRequest
+	4,  // 7: pjse.Exchange.StreamSessionStatus:input_type -> pjse.market.StreamRequest
+	5,  // 8: pjse.Exchange.CreateOrder:output_type -> pjse.orders.OrderResponse
+	5,  // 9: pjse.Exchange.ModifyOrder:output_type -> pjse.orders.OrderResponse
+	5,  // 10: pjse.Exchange.CancelOrder:output_type -> pjse.orders.OrderResponse
+	6,  // 11: pjse.Exchange.GetOpenOrders:output_type -> pjse.orders.OpenOrders
+	7,  // 12: pjse.Exchange.StreamExecutionReports:output_type -> pjse.orders.ExecutionReport
+	8,  // 13: pjse.Exchange.StreamDepth:output_type -> pjse.market.DepthUpdate
+	9,  // 14: pjse.Exchange.StreamTrades:output_type -> pjse.market.Trade
+	10, // 15: pjse.Exchange.StreamSessionStatus:output_type -> pjse.market.SessionStatus
+	8,  // [8:16] is the sub-list for method output_type
+	0,  // [0:8] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protobuf_exchange_proto_init() }
+func file_protobuf_exchange_proto_init() {
+	if File_protobuf_exchange_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protobuf_exchange_proto_rawDesc), len(file_protobuf_exchange_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_protobuf_exchange_proto_goTypes,
+		DependencyIndexes: file_protobuf_exchange_proto_depIdxs,
+	}.Build()
+	File_protobuf_exchange_proto = out.File
+	file_protobuf_exchange_proto_goTypes = nil
+	file_protobuf_exchange_proto_depIdxs = nil
+}
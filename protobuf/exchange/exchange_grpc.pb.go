@@ -0,0 +1,416 @@
+// proto3 문법을 사용한다고 명시합니다.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: protobuf/exchange.proto
+
+package exchange
+
+import (
+	market "PJS_Exchange/protobuf/market"
+	orders "PJS_Exchange/protobuf/orders"
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Exchange_CreateOrder_FullMethodName            = "/pjse.Exchange/CreateOrder"
+	Exchange_ModifyOrder_FullMethodName            = "/pjse.Exchange/ModifyOrder"
+	Exchange_CancelOrder_FullMethodName            = "/pjse.Exchange/CancelOrder"
+	Exchange_GetOpenOrders_FullMethodName          = "/pjse.Exchange/GetOpenOrders"
+	Exchange_StreamExecutionReports_FullMethodName = "/pjse.Exchange/StreamExecutionReports"
+	Exchange_StreamDepth_FullMethodName            = "/pjse.Exchange/StreamDepth"
+	Exchange_StreamTrades_FullMethodName           = "/pjse.Exchange/StreamTrades"
+	Exchange_StreamSessionStatus_FullMethodName    = "/pjse.Exchange/StreamSessionStatus"
+)
+
+// ExchangeClient is the client API for Exchange service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// gRPC API (메타데이터 authorization: Bearer {API_KEY} 로 인증, REST API 와 같은 API 키 권한 필요)
+type ExchangeClient interface {
+	// 주문 (order:create, order:modify, order:cancel)
+	CreateOrder(ctx context.Context, in *orders.CreateOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error)
+	ModifyOrder(ctx context.Context, in *orders.ModifyOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error)
+	CancelOrder(ctx context.Context, in *orders.CancelOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error)
+	// 미체결 주문 조회 (order:read)
+	GetOpenOrders(ctx context.Context, in *orders.OpenOrdersRequest, opts ...grpc.CallOption) (*orders.OpenOrders, error)
+	// 실행 보고서 (order:notify)
+	StreamExecutionReports(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[orders.ExecutionReport], error)
+	// 시장 데이터 (market_data:read, 세션 상태는 권한 필요 없음)
+	StreamDepth(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.DepthUpdate], error)
+	StreamTrades(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.Trade], error)
+	StreamSessionStatus(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.SessionStatus], error)
+}
+
+type exchangeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExchangeClient(cc grpc.ClientConnInterface) ExchangeClient {
+	return &exchangeClient{cc}
+}
+
+func (c *exchangeClient) CreateOrder(ctx context.Context, in *orders.CreateOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(orders.OrderResponse)
+	err := c.cc.Invoke(ctx, Exchange_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) ModifyOrder(ctx context.Context, in *orders.ModifyOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(orders.OrderResponse)
+	err := c.cc.Invoke(ctx, Exchange_ModifyOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) CancelOrder(ctx context.Context, in *orders.CancelOrderRequest, opts ...grpc.CallOption) (*orders.OrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(orders.OrderResponse)
+	err := c.cc.Invoke(ctx, Exchange_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) GetOpenOrders(ctx context.Context, in *orders.OpenOrdersRequest, opts ...grpc.CallOption) (*orders.OpenOrders, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(orders.OpenOrders)
+	err := c.cc.Invoke(ctx, Exchange_GetOpenOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeClient) StreamExecutionReports(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[orders.ExecutionReport], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[0], Exchange_StreamExecutionReports_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[market.StreamRequest, orders.ExecutionReport]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamExecutionReportsClient = grpc.ServerStreamingClient[orders.ExecutionReport]
+
+func (c *exchangeClient) StreamDepth(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.DepthUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[1], Exchange_StreamDepth_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[market.StreamRequest, market.DepthUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamDepthClient = grpc.ServerStreamingClient[market.DepthUpdate]
+
+func (c *exchangeClient) StreamTrades(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.Trade], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[2], Exchange_StreamTrades_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[market.StreamRequest, market.Trade]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamTradesClient = grpc.ServerStreamingClient[market.Trade]
+
+func (c *exchangeClient) StreamSessionStatus(ctx context.Context, in *market.StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[market.SessionStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exchange_ServiceDesc.Streams[3], Exchange_StreamSessionStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[market.StreamRequest, market.SessionStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamSessionStatusClient = grpc.ServerStreamingClient[market.SessionStatus]
+
+// ExchangeServer is the server API for Exchange service.
+// All implementations must embed UnimplementedExchangeServer
+// for forward compatibility.
+//
+// gRPC API (메타데이터 authorization: Bearer {API_KEY} 로 인증, REST API 와 같은 API 키 권한 필요)
+type ExchangeServer interface {
+	// 주문 (order:create, order:modify, order:cancel)
+	CreateOrder(context.Context, *orders.CreateOrderRequest) (*orders.OrderResponse, error)
+	ModifyOrder(context.Context, *orders.ModifyOrderRequest) (*orders.OrderResponse, error)
+	CancelOrder(context.Context, *orders.CancelOrderRequest) (*orders.OrderResponse, error)
+	// 미체결 주문 조회 (order:read)
+	GetOpenOrders(context.Context, *orders.OpenOrdersRequest) (*orders.OpenOrders, error)
+	// 실행 보고서 (order:notify)
+	StreamExecutionReports(*market.StreamRequest, grpc.ServerStreamingServer[orders.ExecutionReport]) error
+	// 시장 데이터 (market_data:read, 세션 상태는 권한 필요 없음)
+	StreamDepth(*market.StreamRequest, grpc.ServerStreamingServer[market.DepthUpdate]) error
+	StreamTrades(*market.StreamRequest, grpc.ServerStreamingServer[market.Trade]) error
+	StreamSessionStatus(*market.StreamRequest, grpc.ServerStreamingServer[market.SessionStatus]) error
+	mustEmbedUnimplementedExchangeServer()
+}
+
+// UnimplementedExchangeServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExchangeServer struct{}
+
+func (UnimplementedExchangeServer) CreateOrder(context.Context, *orders.CreateOrderRequest) (*orders.OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedExchangeServer) ModifyOrder(context.Context, *orders.ModifyOrderRequest) (*orders.OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyOrder not implemented")
+}
+func (UnimplementedExchangeServer) CancelOrder(context.Context, *orders.CancelOrderRequest) (*orders.OrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedExchangeServer) GetOpenOrders(context.Context, *orders.OpenOrdersRequest) (*orders.OpenOrders, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenOrders not implemented")
+}
+func (UnimplementedExchangeServer) StreamExecutionReports(*market.StreamRequest, grpc.ServerStreamingServer[orders.ExecutionReport]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExecutionReports not implemented")
+}
+func (UnimplementedExchangeServer) StreamDepth(*market.StreamRequest, grpc.ServerStreamingServer[market.DepthUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDepth not implemented")
+}
+func (UnimplementedExchangeServer) StreamTrades(*market.StreamRequest, grpc.ServerStreamingServer[market.Trade]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTrades not implemented")
+}
+func (UnimplementedExchangeServer) StreamSessionStatus(*market.StreamRequest, grpc.ServerStreamingServer[market.SessionStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSessionStatus not implemented")
+}
+func (UnimplementedExchangeServer) mustEmbedUnimplementedExchangeServer() {}
+func (UnimplementedExchangeServer) testEmbeddedByValue()                  {}
+
+// UnsafeExchangeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExchangeServer will
+// result in compilation errors.
+type UnsafeExchangeServer interface {
+	mustEmbedUnimplementedExchangeServer()
+}
+
+func RegisterExchangeServer(s grpc.ServiceRegistrar, srv ExchangeServer) {
+	// If the following call pancis, it indicates UnimplementedExchangeServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Exchange_ServiceDesc, srv)
+}
+
+func _Exchange_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(orders.CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exchange_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).CreateOrder(ctx, req.(*orders.CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_ModifyOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(orders.ModifyOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).ModifyOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exchange_ModifyOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).ModifyOrder(ctx, req.(*orders.ModifyOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(orders.CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exchange_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).CancelOrder(ctx, req.(*orders.CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_GetOpenOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(orders.OpenOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServer).GetOpenOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exchange_GetOpenOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServer).GetOpenOrders(ctx, req.(*orders.OpenOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exchange_StreamExecutionReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(market.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).StreamExecutionReports(m, &grpc.GenericServerStream[market.StreamRequest, orders.ExecutionReport]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamExecutionReportsServer = grpc.ServerStreamingServer[orders.ExecutionReport]
+
+func _Exchange_StreamDepth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(market.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).StreamDepth(m, &grpc.GenericServerStream[market.StreamRequest, market.DepthUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamDepthServer = grpc.ServerStreamingServer[market.DepthUpdate]
+
+func _Exchange_StreamTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(market.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).StreamTrades(m, &grpc.GenericServerStream[market.StreamRequest, market.Trade]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamTradesServer = grpc.ServerStreamingServer[market.Trade]
+
+func _Exchange_StreamSessionStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(market.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServer).StreamSessionStatus(m, &grpc.GenericServerStream[market.StreamRequest, market.SessionStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exchange_StreamSessionStatusServer = grpc.ServerStreamingServer[market.SessionStatus]
+
+// Exchange_ServiceDesc is the grpc.ServiceDesc for Exchange service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Exchange_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pjse.Exchange",
+	HandlerType: (*ExchangeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Exchange_CreateOrder_Handler,
+		},
+		{
+			MethodName: "ModifyOrder",
+			Handler:    _Exchange_ModifyOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Exchange_CancelOrder_Handler,
+		},
+		{
+			MethodName: "GetOpenOrders",
+			Handler:    _Exchange_GetOpenOrders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExecutionReports",
+			Handler:       _Exchange_StreamExecutionReports_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamDepth",
+			Handler:       _Exchange_StreamDepth_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTrades",
+			Handler:       _Exchange_StreamTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSessionStatus",
+			Handler:       _Exchange_StreamSessionStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protobuf/exchange.proto",
+}
@@ -0,0 +1,52 @@
+package market
+
+import (
+	t "PJS_Exchange/template"
+)
+
+// FromUpdateDepth 호가 갱신을 protobuf 메시지로 변환
+func FromUpdateDepth(depth t.UpdateDepth) *DepthUpdate {
+	return &DepthUpdate{
+		Timestamp: depth.Timestamp,
+		Symbol:    depth.Symbol,
+		Side:      depth.Side,
+		Price:     int64(depth.Price),
+		Quantity:  int64(depth.Quantity),
+	}
+}
+
+// FromAuctionIndicative 단일가 매매 예상 체결가를 protobuf 메시지로 변환 (side 는 "auction")
+func FromAuctionIndicative(indicative t.AuctionIndicative) *DepthUpdate {
+	return &DepthUpdate{
+		Timestamp: indicative.Timestamp,
+		Symbol:    indicative.Symbol,
+		Side:      indicative.Side,
+		Price:     int64(indicative.Price),
+		Quantity:  int64(indicative.Quantity),
+		Phase:     indicative.Phase,
+		Imbalance: int64(indicative.Imbalance),
+	}
+}
+
+// FromLedger 체결 내역을 protobuf 메시지로 변환 (매수자, 매도자 ID 는 공개하지 않음)
+func FromLedger(ledger t.Ledger) *Trade {
+	return &Trade{
+		Timestamp:   ledger.Timestamp,
+		Symbol:      ledger.Symbol,
+		Price:       int64(ledger.Price),
+		Volume:      int64(ledger.Volume),
+		Side:        ledger.Side,
+		ExecutionId: ledger.ExecutionID,
+		BuyOrderId:  ledger.BuyOrderID,
+		SellOrderId: ledger.SellOrderID,
+		Conditions:  ledger.Conditions,
+	}
+}
+
+func FromSessionStatus(status t.SessionStatus) *SessionStatus {
+	return &SessionStatus{
+		Session:  status.Session,
+		Reason:   status.Reason,
+		ResumeAt: status.ResumeAt,
+	}
+}
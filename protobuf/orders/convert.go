@@ -0,0 +1,59 @@
+package orders
+
+import (
+	t "PJS_Exchange/template"
+)
+
+// FromExecutionReport 실행 보고서를 protobuf 메시지로 변환
+func FromExecutionReport(report t.ExecutionReport) *ExecutionReport {
+	return &ExecutionReport{
+		Seq:             report.Seq,
+		Timestamp:       report.Timestamp,
+		ExecType:        report.ExecType,
+		ExecutionId:     report.ExecutionID,
+		OrderId:         report.OrderID,
+		ClientOrderId:   report.ClientOrderID,
+		Symbol:          report.Symbol,
+		Side:            report.Side,
+		Type:            report.OrderType,
+		Status:          report.Status,
+		Price:           int64(report.Price),
+		StopPrice:       int64(report.StopPrice),
+		TimeInForce:     report.TimeInForce,
+		ExpireAt:        report.ExpireAt,
+		PostOnly:        report.PostOnly,
+		DisplayQuantity: int64(report.DisplayQuantity),
+		Quantity:        int64(report.Quantity),
+		LastPrice:       int64(report.LastPrice),
+		LastQuantity:    int64(report.LastQuantity),
+		CumQuantity:     int64(report.CumQuantity),
+		LeavesQuantity:  int64(report.LeavesQuantity),
+		AvgPrice:        report.AvgPrice,
+		Reason:          report.Reason,
+		ReasonCode:      report.ReasonCode,
+	}
+}
+
+// FromOpenOrder 미체결 주문을 protobuf 메시지로 변환
+func FromOpenOrder(order t.OpenOrder) *OpenOrder {
+	return &OpenOrder{
+		OrderId:             order.OrderID,
+		ClientOrderId:       order.ClientOrderID,
+		Symbol:              order.Symbol,
+		Side:                order.Side,
+		Type:                order.OrderType,
+		Price:               int64(order.Price),
+		StopPrice:           int64(order.StopPrice),
+		TimeInForce:         order.TimeInForce,
+		ExpireAt:            order.ExpireAt,
+		PostOnly:            order.PostOnly,
+		DisplayQuantity:     int64(order.DisplayQuantity),
+		SelfTradePrevention: order.SelfTradePrevention,
+		OriginalQuantity:    int64(order.OriginalQuantity),
+		RemainingQuantity:   int64(order.RemainingQuantity),
+		AvgPrice:            order.AvgPrice,
+		Status:              order.Status,
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+	}
+}
@@ -0,0 +1,1076 @@
+// proto3 문법을 사용한다고 명시합니다.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: protobuf/orders.proto
+
+package orders
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 신규 주문 (REST API 의 CreateOrderRequest 와 같음)
+type CreateOrderRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Symbol              string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                      // 종목 코드
+	Side                string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`                                          // "buy" or "sell"
+	ClientOrderId       string                 `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // 사용자별 하루 동안 고유한 주문 ID (최대 64자, 영문, 숫자, - _ . : 만 가능)
+	Type                string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                                          // "limit", "market", "stop", "stop_limit"
+	Price               int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`                                       // 주문 가격 (고정 소수점, 거래소 price_precision 기준)
+	StopPrice           int64                  `protobuf:"varint,6,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`              // "stop", "stop_limit" 만
+	Quantity            int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TimeInForce         string                 `protobuf:"bytes,8,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"`                          // "DAY", "GTC", "GTD", "IOC", "FOK"
+	ExpireAt            int64                  `protobuf:"varint,9,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`                                    // GTD 만 (Unix milli)
+	PostOnly            string                 `protobuf:"bytes,10,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`                                    // "reject" or "reprice"
+	DisplayQuantity     int64                  `protobuf:"varint,11,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`              // 아이스버그 주문의 호가 노출 수량
+	SelfTradePrevention string                 `protobuf:"bytes,12,opt,name=self_trade_prevention,json=selfTradePrevention,proto3" json:"self_trade_prevention,omitempty"` // 생략 시 사용자 기본값
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_protobuf_orders_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetPostOnly() string {
+	if x != nil {
+		return x.PostOnly
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetSelfTradePrevention() string {
+	if x != nil {
+		return x.SelfTradePrevention
+	}
+	return ""
+}
+
+// 주문 정정 (order_id 대신 client_order_id 사용 가능)
+type ModifyOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,4,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Price         int64                  `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice     int64                  `protobuf:"varint,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"` // 발동 대기 중인 스탑 주문만 (0이면 기존 값 유지)
+	Quantity      int64                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifyOrderRequest) Reset() {
+	*x = ModifyOrderRequest{}
+	mi := &file_protobuf_orders_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyOrderRequest) ProtoMessage() {}
+
+func (x *ModifyOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyOrderRequest.ProtoReflect.Descriptor instead.
+func (*ModifyOrderRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ModifyOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ModifyOrderRequest) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *ModifyOrderRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// 주문 취소 (order_id 대신 client_order_id 사용 가능)
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,4,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_protobuf_orders_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancelOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+// 주문 요청 처리 결과 (매칭 엔진의 접수 / 거절 결과)
+type OrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Code          int32                  `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"` // REST API 의 HTTP 응답 코드와 같음
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,6,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"` // 거절 사유 코드
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                    // Unix milli
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderResponse) Reset() {
+	*x = OrderResponse{}
+	mi := &file_protobuf_orders_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderResponse) ProtoMessage() {}
+
+func (x *OrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
+func (*OrderResponse) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *OrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *OrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 미체결 주문 조회 (symbol 을 비우면 전체 심볼)
+type OpenOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenOrdersRequest) Reset() {
+	*x = OpenOrdersRequest{}
+	mi := &file_protobuf_orders_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenOrdersRequest) ProtoMessage() {}
+
+func (x *OpenOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenOrdersRequest.ProtoReflect.Descriptor instead.
+func (*OpenOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OpenOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type OpenOrder struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrderId             string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId       string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Symbol              string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side                string                 `protobuf:"bytes,4,opt,name=side,proto3" json:"side,omitempty"`
+	Type                string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Price               int64                  `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice           int64                  `protobuf:"varint,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TimeInForce         string                 `protobuf:"bytes,8,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"`
+	ExpireAt            int64                  `protobuf:"varint,9,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	PostOnly            string                 `protobuf:"bytes,10,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	DisplayQuantity     int64                  `protobuf:"varint,11,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	SelfTradePrevention string                 `protobuf:"bytes,12,opt,name=self_trade_prevention,json=selfTradePrevention,proto3" json:"self_trade_prevention,omitempty"`
+	OriginalQuantity    int64                  `protobuf:"varint,13,opt,name=original_quantity,json=originalQuantity,proto3" json:"original_quantity,omitempty"`
+	RemainingQuantity   int64                  `protobuf:"varint,14,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"`
+	AvgPrice            float64                `protobuf:"fixed64,15,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	Status              string                 `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"` // "open" or "partially_filled"
+	CreatedAt           int64                  `protobuf:"varint,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           int64                  `protobuf:"varint,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OpenOrder) Reset() {
+	*x = OpenOrder{}
+	mi := &file_protobuf_orders_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenOrder) ProtoMessage() {}
+
+func (x *OpenOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenOrder.ProtoReflect.Descriptor instead.
+func (*OpenOrder) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OpenOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetPostOnly() string {
+	if x != nil {
+		return x.PostOnly
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetSelfTradePrevention() string {
+	if x != nil {
+		return x.SelfTradePrevention
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetOriginalQuantity() int64 {
+	if x != nil {
+		return x.OriginalQuantity
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetRemainingQuantity() int64 {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetAvgPrice() float64 {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OpenOrder) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *OpenOrder) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type OpenOrders struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*OpenOrder           `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenOrders) Reset() {
+	*x = OpenOrders{}
+	mi := &file_protobuf_orders_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenOrders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenOrders) ProtoMessage() {}
+
+func (x *OpenOrders) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenOrders.ProtoReflect.Descriptor instead.
+func (*OpenOrders) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OpenOrders) GetOrders() []*OpenOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+// 주문 상태 변경 / 체결 알림 (/ws/notify 의 실행 보고서와 같음)
+type ExecutionReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Seq             int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // 사용자별로 1씩 증가 (누락 확인용)
+	Timestamp       int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExecType        string                 `protobuf:"bytes,3,opt,name=exec_type,json=execType,proto3" json:"exec_type,omitempty"` // "new", "partial", "fill", "cancel", "reject", "replace", "triggered", "restated"
+	ExecutionId     string                 `protobuf:"bytes,4,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	OrderId         string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId   string                 `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Symbol          string                 `protobuf:"bytes,7,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side            string                 `protobuf:"bytes,8,opt,name=side,proto3" json:"side,omitempty"`
+	Type            string                 `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	Status          string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Price           int64                  `protobuf:"varint,11,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice       int64                  `protobuf:"varint,12,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TimeInForce     string                 `protobuf:"bytes,13,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"`
+	ExpireAt        int64                  `protobuf:"varint,14,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	PostOnly        string                 `protobuf:"bytes,15,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	DisplayQuantity int64                  `protobuf:"varint,16,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`
+	Quantity        int64                  `protobuf:"varint,17,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LastPrice       int64                  `protobuf:"varint,18,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastQuantity    int64                  `protobuf:"varint,19,opt,name=last_quantity,json=lastQuantity,proto3" json:"last_quantity,omitempty"`
+	CumQuantity     int64                  `protobuf:"varint,20,opt,name=cum_quantity,json=cumQuantity,proto3" json:"cum_quantity,omitempty"`
+	LeavesQuantity  int64                  `protobuf:"varint,21,opt,name=leaves_quantity,json=leavesQuantity,proto3" json:"leaves_quantity,omitempty"`
+	AvgPrice        float64                `protobuf:"fixed64,22,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	Reason          string                 `protobuf:"bytes,23,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReasonCode      string                 `protobuf:"bytes,24,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExecutionReport) Reset() {
+	*x = ExecutionReport{}
+	mi := &file_protobuf_orders_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionReport) ProtoMessage() {}
+
+func (x *ExecutionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_orders_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionReport.ProtoReflect.Descriptor instead.
+func (*ExecutionReport) Descriptor() ([]byte, []int) {
+	return file_protobuf_orders_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecutionReport) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetExecType() string {
+	if x != nil {
+		return x.ExecType
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetStopPrice() int64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetPostOnly() string {
+	if x != nil {
+		return x.PostOnly
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetDisplayQuantity() int64 {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetLastPrice() int64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetLastQuantity() int64 {
+	if x != nil {
+		return x.LastQuantity
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetCumQuantity() int64 {
+	if x != nil {
+		return x.CumQuantity
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetLeavesQuantity() int64 {
+	if x != nil {
+		return x.LeavesQuantity
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetAvgPrice() float64 {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return 0
+}
+
+func (x *ExecutionReport) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ExecutionReport) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+var File_protobuf_orders_proto protoreflect.FileDescriptor
+
+const file_protobuf_orders_proto_rawDesc = "" +
+	"\n" +
+	"\x15protobuf/orders.proto\x12\vpjse.orders\"\x8a\x03\n" +
+	"\x12CreateOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\x06 \x01(\x03R\tstopPrice\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x12\"\n" +
+	"\rtime_in_force\x18\b \x01(\tR\vtimeInForce\x12\x1b\n" +
+	"\texpire_at\x18\t \x01(\x03R\bexpireAt\x12\x1b\n" +
+	"\tpost_only\x18\n" +
+	" \x01(\tR\bpostOnly\x12)\n" +
+	"\x10display_quantity\x18\v \x01(\x03R\x0fdisplayQuantity\x122\n" +
+	"\x15self_trade_prevention\x18\f \x01(\tR\x13selfTradePrevention\"\xe8\x01\n" +
+	"\x12ModifyOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x04 \x01(\tR\rclientOrderId\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\a \x01(\x03R\tstopPrice\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x03R\bquantity\"\x83\x01\n" +
+	"\x12CancelOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x04 \x01(\tR\rclientOrderId\"\xd9\x01\n" +
+	"\rOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1f\n" +
+	"\vreason_code\x18\x06 \x01(\tR\n" +
+	"reasonCode\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"+\n" +
+	"\x11OpenOrdersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\xcf\x04\n" +
+	"\tOpenOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x04 \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\a \x01(\x03R\tstopPrice\x12\"\n" +
+	"\rtime_in_force\x18\b \x01(\tR\vtimeInForce\x12\x1b\n" +
+	"\texpire_at\x18\t \x01(\x03R\bexpireAt\x12\x1b\n" +
+	"\tpost_only\x18\n" +
+	" \x01(\tR\bpostOnly\x12)\n" +
+	"\x10display_quantity\x18\v \x01(\x03R\x0fdisplayQuantity\x122\n" +
+	"\x15self_trade_prevention\x18\f \x01(\tR\x13selfTradePrevention\x12+\n" +
+	"\x11original_quantity\x18\r \x01(\x03R\x10originalQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\x0e \x01(\x03R\x11remainingQuantity\x12\x1b\n" +
+	"\tavg_price\x18\x0f \x01(\x01R\bavgPrice\x12\x16\n" +
+	"\x06status\x18\x10 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\x03R\tupdatedAt\"<\n" +
+	"\n" +
+	"OpenOrders\x12.\n" +
+	"\x06orders\x18\x01 \x03(\v2\x16.pjse.orders.OpenOrderR\x06orders\"\xdc\x05\n" +
+	"\x0fExecutionReport\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\texec_type\x18\x03 \x01(\tR\bexecType\x12!\n" +
+	"\fexecution_id\x18\x04 \x01(\tR\vexecutionId\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x06 \x01(\tR\rclientOrderId\x12\x16\n" +
+	"\x06symbol\x18\a \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\b \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x14\n" +
+	"\x05price\x18\v \x01(\x03R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\f \x01(\x03R\tstopPrice\x12\"\n" +
+	"\rtime_in_force\x18\r \x01(\tR\vtimeInForce\x12\x1b\n" +
+	"\texpire_at\x18\x0e \x01(\x03R\bexpireAt\x12\x1b\n" +
+	"\tpost_only\x18\x0f \x01(\tR\bpostOnly\x12)\n" +
+	"\x10display_quantity\x18\x10 \x01(\x03R\x0fdisplayQuantity\x12\x1a\n" +
+	"\bquantity\x18\x11 \x01(\x03R\bquantity\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x12 \x01(\x03R\tlastPrice\x12#\n" +
+	"\rlast_quantity\x18\x13 \x01(\x03R\flastQuantity\x12!\n" +
+	"\fcum_quantity\x18\x14 \x01(\x03R\vcumQuantity\x12'\n" +
+	"\x0fleaves_quantity\x18\x15 \x01(\x03R\x0eleavesQuantity\x12\x1b\n" +
+	"\tavg_price\x18\x16 \x01(\x01R\bavgPrice\x12\x16\n" +
+	"\x06reason\x18\x17 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vreason_code\x18\x18 \x01(\tR\n" +
+	"reasonCodeB\x1eZ\x1cPJS_Exchange/protobuf/ordersb\x06proto3"
+
+var (
+	file_protobuf_orders_proto_rawDescOnce sync.Once
+	file_protobuf_orders_proto_rawDescData []byte
+)
+
+func file_protobuf_orders_proto_rawDescGZIP() []byte {
+	file_protobuf_orders_proto_rawDescOnce.Do(func() {
+		file_protobuf_orders_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protobuf_orders_proto_rawDesc), len(file_protobuf_orders_proto_rawDesc)))
+	})
+	return file_protobuf_orders_proto_rawDescData
+}
+
+var file_protobuf_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_protobuf_orders_proto_goTypes = []any{
+	(*CreateOrderRequest)(nil), // 0: pjse.orders.CreateOrderRequest
+	(*ModifyOrderRequest)(nil), // 1: pjse.orders.ModifyOrderRequest
+	(*CancelOrderRequest)(nil), // 2: pjse.orders.CancelOrderRequest
+	(*OrderResponse)(nil),      // 3: pjse.orders.OrderResponse
+	(*OpenOrdersRequest)(nil),  // 4: pjse.orders.OpenOrdersRequest
+	(*OpenOrder)(nil),          // 5: pjse.orders.OpenOrder
+	(*OpenOrders)(nil),         // 6: pjse.orders.OpenOrders
+	(*ExecutionReport)(nil),    // 7: pjse.orders.ExecutionReport
+}
+var file_protobuf_orders_proto_depIdxs = []int32{
+	5, // 0: pjse.orders.OpenOrders.orders:type_name -> pjse.orders.OpenOrder
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protobuf_orders_proto_init() }
+func file_protobuf_orders_proto_init() {
+	if File_protobuf_orders_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protobuf_orders_proto_rawDesc), len(file_protobuf_orders_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protobuf_orders_proto_goTypes,
+		DependencyIndexes: file_protobuf_orders_proto_depIdxs,
+		MessageInfos:      file_protobuf_orders_proto_msgTypes,
+	}.Build()
+	File_protobuf_orders_proto = out.File
+	file_protobuf_orders_proto_goTypes = nil
+	file_protobuf_orders_proto_depIdxs = nil
+}
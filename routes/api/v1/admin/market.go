@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/exchanges/channels"
+	"PJS_Exchange/middlewares/auth"
+	"PJS_Exchange/template"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MarketRouter struct{}
+
+func (mr *MarketRouter) RegisterRoutes(router fiber.Router) {
+	adminMarketGroup := router.Group("/market")
+
+	adminMarketGroup.Get("/halt",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			AdminSystemRead: true,
+		}), mr.haltStatus)
+	adminMarketGroup.Post("/halt",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			AdminSystemWrite: true,
+		}), mr.haltMarket)
+	adminMarketGroup.Post("/resume",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			AdminSystemWrite: true,
+		}), mr.resumeMarket)
+}
+
+// === 핸들러 함수들 ===
+
+// @Summary		거래 중단 상태 조회
+// @Description	거래소 전체 거래 중단 (서킷 브레이커) 상태를 반환합니다.
+// @Tags			Admin - Market
+// @Produce		json
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"	with	AdminSystemRead	Scope
+// @Success		200				{object}	map[string]exchanges.TradingHalt	"성공 시 거래 중단 상태 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/market/halt [get]
+func (mr *MarketRouter) haltStatus(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"halt": exchanges.GetHalt(),
+	})
+}
+
+// @Summary		거래 중단 (서킷 브레이커)
+// @Description	모든 심볼의 매칭을 중단하고 주문 접수를 막습니다 (취소는 가능). 세션 WebSocket 으로 halt 알림을 보냅니다.
+// @Description	minutes 를 지정하면 해당 시간 후 자동으로 재개하며 (재개 5분 전, 1분 전 알림), 재개 시 겹친 호가는 단일가로 체결합니다.
+// @Description	이미 중단 중이면 사유와 재개 시각만 변경합니다.
+// @Tags			Admin - Market
+// @Produce		json
+// @Param			reason			query		string				true	"거래 중단 사유"
+// @Param			minutes			query		int					false	"재개까지 남은 시간 (분, 0 또는 생략 시 관리자가 재개할 때까지 유지)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"	with	AdminSystemWrite	Scope
+// @Success		200				{object}	map[string]interface{}	"성공 시 거래 중단 상태 반환"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/market/halt [post]
+func (mr *MarketRouter) haltMarket(c *fiber.Ctx) error {
+	reason := c.Query("reason")
+	if reason == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "reason query parameter is required")
+	}
+	minutes := 0
+	if minutesQueryParam := c.Query("minutes"); minutesQueryParam != "" {
+		var err error
+		minutes, err = strconv.Atoi(minutesQueryParam)
+		if err != nil || minutes < 0 {
+			return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid minutes value")
+		}
+	}
+
+	halt := channels.OP.HaltMarket(reason, "admin", time.Duration(minutes)*time.Minute)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Market halted successfully",
+		"halt":    halt,
+	})
+}
+
+// @Summary		거래 재개
+// @Description	거래 중단을 해제하고 현재 세션으로 복귀합니다. 겹친 호가는 단일가로 체결하며 세션 WebSocket 으로 resume 알림을 보냅니다.
+// @Tags			Admin - Market
+// @Produce		json
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"	with	AdminSystemWrite	Scope
+// @Success		200				{object}	map[string]string	"성공 시 거래 재개 메시지 반환"
+// @Failure		409				{object}	map[string]string	"거래 중단 중이 아닐 때 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/market/resume [post]
+func (mr *MarketRouter) resumeMarket(c *fiber.Ctx) error {
+	if !channels.OP.ResumeMarket() {
+		return template.ErrorHandler(c, fiber.StatusConflict, "Market is not halted")
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Market resumed successfully",
+	})
+}
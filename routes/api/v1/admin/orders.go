@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges/channels"
+	"PJS_Exchange/middlewares/auth"
+	"PJS_Exchange/template"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderRouter struct{}
+
+func (or *OrderRouter) RegisterRoutes(router fiber.Router) {
+	adminOrderGroup := router.Group("/orders", auth.APIKeyMiddlewareRequireScopes(auth.Config{
+		Bypass: false,
+	}, postgresql.APIKeyScope{
+		AdminSystemWrite: true,
+	}))
+
+	adminOrderGroup.Delete("/", or.cancelOrders)
+}
+
+// === 핸들러 함수들 ===
+
+// @Summary		주문 일괄 취소
+// @Description	특정 유저 또는 심볼의 미체결 주문(발동 대기 중인 스탑 주문 포함)을 모두 취소합니다. user_id, symbol 중 하나 이상 지정해야 하며, side를 지정하면 해당 방향의 주문만 취소합니다.
+// @Description	장 상태, 거래 중단 여부와 상관없이 취소하며, 취소된 주문마다 /ws/notify 로 취소 알림이 전송됩니다.
+// @Tags			Admin - Order
+// @Produce		json
+// @Param			user_id			query		int					false	"유저 ID"
+// @Param			symbol			query		string				false	"심볼 (예: NVDA)"
+// @Param			side			query		string				false	"buy or sell (생략 시 모두)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"	with	AdminSystemWrite	Scope
+// @Success		200				{object}	map[string]interface{}	"성공 시 취소된 주문 ID 목록 반환"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/orders [delete]
+func (or *OrderRouter) cancelOrders(c *fiber.Ctx) error {
+	userID := 0
+	if userIDQueryParam := c.Query("user_id"); userIDQueryParam != "" {
+		var err error
+		userID, err = strconv.Atoi(userIDQueryParam)
+		if err != nil || userID <= 0 {
+			return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid user_id value")
+		}
+	}
+	symbol := c.Query("symbol")
+	if userID == 0 && symbol == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "user_id or symbol query parameter is required")
+	}
+	side := c.Query("side")
+	if side != "" && side != template.SideBuy && side != template.SideSell {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid side value")
+	}
+
+	canceled := channels.OP.CancelOrders(symbol, userID, side, "Canceled by admin (mass cancel)")
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Orders canceled successfully",
+		"canceled": canceled,
+		"count":    len(canceled),
+	})
+}
@@ -3,16 +3,15 @@ package admin
 import (
 	"PJS_Exchange/app/postgresApp"
 	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/exchanges/channels"
 	"PJS_Exchange/middlewares/auth"
 	"PJS_Exchange/routes/ws"
 	"PJS_Exchange/template"
-	"PJS_Exchange/utils"
-	"encoding/json"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/websocket/v2"
 )
 
 type SymbolRouter struct{}
@@ -33,6 +32,8 @@ func (sr *SymbolRouter) RegisterRoutes(router fiber.Router) {
 	adminSymbolGroup.Patch("/:symbol/status/inactivate", sr.readyTradeSymbol)
 	adminSymbolGroup.Patch("/:symbol/tick-size", sr.setTickSizeSymbol)
 	adminSymbolGroup.Patch("/:symbol/minimum-order-quantity", sr.setMinimumOrderQuantitySymbol)
+	adminSymbolGroup.Patch("/:symbol/price-limit", sr.setPriceLimitSymbol)
+	adminSymbolGroup.Patch("/:symbol/volatility-band", sr.setVolatilityBandSymbol)
 }
 
 // === 핸들러 함수들 ===
@@ -146,6 +147,9 @@ func (sr *SymbolRouter) readyTradeSymbol(c *fiber.Ctx) error {
 	if err != nil {
 		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update symbol status: "+err.Error())
 	}
+
+	// 매칭 엔진 정지
+	channels.OP.StopSymbol(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Symbol status updated to inactive",
 	})
@@ -172,7 +176,7 @@ func (sr *SymbolRouter) enableTradeSymbol(c *fiber.Ctx) error {
 	if c.GetRespHeader("price", "") != "" {
 		priceHeader := c.GetRespHeader("price", "")
 		price, err := strconv.ParseFloat(priceHeader, 64)
-		if err != nil || price <= 0 {
+		if err != nil || price <= 0 || !exchanges.IsPriceRepresentable(price) {
 			return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid price value")
 		}
 
@@ -188,21 +192,20 @@ func (sr *SymbolRouter) enableTradeSymbol(c *fiber.Ctx) error {
 		ledger := template.Ledger{
 			Timestamp: time.Now().UnixMilli(),
 			Symbol:    symbolParam,
-			Price:     price,
+			Price:     exchanges.ToPrice(price),
 			Volume:    0,
 		}
-		send, _ := json.Marshal(ledger)
-		if ws.TempLedger[symbolParam] == nil {
-			ws.TempLedger[symbolParam] = utils.NewChunkedStore[template.Ledger](128)
-		}
-		ws.TempLedger[symbolParam].Append(ledger)
-		ws.LedgerHub.BroadcastMessage(time.Now().UnixMilli(), websocket.TextMessage, send)
+		ws.AppendTempLedger(ledger)
+		ws.LedgerHub.BroadcastMessage(time.Now().UnixMilli(), ws.LedgerPayload(ledger))
 	}
 
 	_ = postgresApp.Get().SymbolRepo().UpdateSymbolStatus(c.Context(), symbolParam, postgresql.Status{
 		Status: postgresql.StatusActive,
 		Reason: "",
 	})
+
+	// 매칭 엔진 시작
+	channels.OP.StartSymbol(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Symbol status updated to active",
 	})
@@ -234,6 +237,9 @@ func (sr *SymbolRouter) disableTradeSymbol(c *fiber.Ctx) error {
 	if err != nil {
 		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update symbol status: "+err.Error())
 	}
+
+	// 매칭 엔진 정지 (거래 재개시 기존 호가 유지)
+	channels.OP.StopSymbol(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Symbol status updated to suspended",
 		"reason":  reasonHeader,
@@ -266,6 +272,9 @@ func (sr *SymbolRouter) symbolDelisting(c *fiber.Ctx) error {
 	if err != nil {
 		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to delist symbol: "+err.Error())
 	}
+
+	// 매칭 엔진 정지 및 호가 삭제
+	channels.OP.RemoveSymbol(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Symbol delisted successfully",
 		"reason":  reasonHeader,
@@ -294,7 +303,7 @@ func (sr *SymbolRouter) setTickSizeSymbol(c *fiber.Ctx) error {
 		return template.ErrorHandler(c, fiber.StatusBadRequest, "tick_size query parameter is required")
 	}
 	tickSize, err := strconv.ParseFloat(tickSizeQueryParam, 32)
-	if err != nil || tickSize <= 0 {
+	if err != nil || tickSize <= 0 || !exchanges.IsPriceRepresentable(tickSize) { // 통화 최소 단위보다 작은 틱 사이즈는 불가
 		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid tick_size value")
 	}
 
@@ -302,6 +311,9 @@ func (sr *SymbolRouter) setTickSizeSymbol(c *fiber.Ctx) error {
 	if err != nil {
 		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update tick size: "+err.Error())
 	}
+
+	// 매칭 엔진에 반영
+	channels.OP.RefreshSymbolParams(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message":   "Tick size updated successfully",
 		"tick_size": tickSize,
@@ -338,8 +350,89 @@ func (sr *SymbolRouter) setMinimumOrderQuantitySymbol(c *fiber.Ctx) error {
 	if err != nil {
 		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update minimum order quantity: "+err.Error())
 	}
+
+	// 매칭 엔진에 반영
+	channels.OP.RefreshSymbolParams(symbolParam)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message":                "Minimum order quantity updated successfully",
 		"minimum_order_quantity": minOrderQty,
 	})
 }
+
+// @Summary		일일 가격 제한폭 설정
+// @Description	심볼의 일일 가격 제한폭(전일 종가 대비 %)을 설정합니다. 상한가 / 하한가를 벗어난 지정가 주문은 거절됩니다. (reason_code: price_out_of_limit, 0이면 제한 없음)
+// @Tags			Admin - Symbol
+// @Produce		json
+// @Param			symbol				path		string				true	"심볼 (예: NVDA)"
+// @Param			price_limit_percent	query		number				true	"가격 제한폭 % (예: 30)"
+// @Param			Authorization		header		string				true	"Bearer {API_KEY}"	with	AdminSymbolManage	Scope
+// @Success		200					{object}	map[string]interface{}	"성공 시 가격 제한폭 변경 메시지 반환"
+// @Failure		400					{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		500					{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Failure		401					{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/symbol/{symbol}/price-limit [patch]
+func (sr *SymbolRouter) setPriceLimitSymbol(c *fiber.Ctx) error {
+	symbolParam := c.Params("symbol")
+	if symbolParam == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Symbol parameter is required")
+	}
+	percentQueryParam := c.Query("price_limit_percent")
+	if percentQueryParam == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "price_limit_percent query parameter is required")
+	}
+	percent, err := strconv.ParseFloat(percentQueryParam, 32)
+	if err != nil || percent < 0 || percent >= 100 {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid price_limit_percent value")
+	}
+
+	err = postgresApp.Get().SymbolRepo().SetPriceLimitPercent(c.Context(), symbolParam, float32(percent))
+	if err != nil {
+		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update price limit: "+err.Error())
+	}
+
+	// 매칭 엔진에 반영
+	channels.OP.RefreshSymbolParams(symbolParam)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":             "Price limit updated successfully",
+		"price_limit_percent": percent,
+	})
+}
+
+// @Summary		변동성 완화장치 기준 설정
+// @Description	심볼의 변동성 완화장치 발동 기준(직전 체결가 대비 %)을 설정합니다. 체결 가격이 기준을 벗어나면 체결을 멈추고 일정 시간 동안 단일가 매매(cooldown) 후 접속 매매를 재개합니다. (0이면 사용 안함)
+// @Tags			Admin - Symbol
+// @Produce		json
+// @Param			symbol					path		string				true	"심볼 (예: NVDA)"
+// @Param			volatility_band_percent	query		number				true	"발동 기준 % (예: 5)"
+// @Param			Authorization			header		string				true	"Bearer {API_KEY}"	with	AdminSymbolManage	Scope
+// @Success		200						{object}	map[string]interface{}	"성공 시 변동성 완화장치 기준 변경 메시지 반환"
+// @Failure		400						{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		500						{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Failure		401						{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/symbol/{symbol}/volatility-band [patch]
+func (sr *SymbolRouter) setVolatilityBandSymbol(c *fiber.Ctx) error {
+	symbolParam := c.Params("symbol")
+	if symbolParam == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Symbol parameter is required")
+	}
+	percentQueryParam := c.Query("volatility_band_percent")
+	if percentQueryParam == "" {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "volatility_band_percent query parameter is required")
+	}
+	percent, err := strconv.ParseFloat(percentQueryParam, 32)
+	if err != nil || percent < 0 || percent >= 100 {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid volatility_band_percent value")
+	}
+
+	err = postgresApp.Get().SymbolRepo().SetVolatilityBandPercent(c.Context(), symbolParam, float32(percent))
+	if err != nil {
+		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update volatility band: "+err.Error())
+	}
+
+	// 매칭 엔진에 반영
+	channels.OP.RefreshSymbolParams(symbolParam)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":                 "Volatility band updated successfully",
+		"volatility_band_percent": percent,
+	})
+}
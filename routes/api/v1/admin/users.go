@@ -3,6 +3,7 @@ package admin
 import (
 	"PJS_Exchange/app/postgresApp"
 	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges/channels"
 	"PJS_Exchange/middlewares/auth"
 	"PJS_Exchange/template"
 	"time"
@@ -25,6 +26,7 @@ func (ur *UserRouter) RegisterRoutes(router fiber.Router) {
 	adminUserGroup.Post("/", ur.generateAccessCode)
 	adminUserGroup.Patch("/:id/activate", ur.activateUser)     // 유저 활성화
 	adminUserGroup.Patch("/:id/deactivate", ur.deactivateUser) // 유저 비
+	adminUserGroup.Patch("/:id/self-trade-prevention", ur.setSelfTradePrevention)
 }
 
 // === 핸들러 함수들 ===
@@ -148,3 +150,39 @@ func (ur *UserRouter) deactivateUser(c *fiber.Ctx) error {
 		"message": "User deactivated successfully",
 	})
 }
+
+// @Summary		유저 기본 자기매매 방지 모드 설정
+// @Description	주문에 self_trade_prevention을 지정하지 않은 경우 사용할 유저(브로커)의 기본 자기매매 방지 모드를 설정합니다.
+// @Description	cancel_newest: 들어온 주문 취소, cancel_oldest: 호가에 있던 주문 취소, cancel_both: 둘 다 취소, decrement: 겹치는 수량만큼 둘 다 감소, none 또는 빈 값: 사용 안함
+// @Tags			Admin - User
+// @Produce		json
+// @Param			id				path		int					true	"유저 ID"
+// @Param			mode			query		string				false	"자기매매 방지 모드"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"	with	AdminUserManage	Scope
+// @Success		200				{object}	map[string]string	"성공 시 성공 메시지 반환"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Router			/api/v1/admin/user/{id}/self-trade-prevention [patch]
+func (ur *UserRouter) setSelfTradePrevention(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid user ID")
+	}
+	mode := c.Query("mode")
+	if !channels.ValidSelfTradePrevention(mode) {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid mode value")
+	}
+	if mode == template.STPNone {
+		mode = ""
+	}
+
+	err = postgresApp.Get().UserRepo().SetSelfTradePrevention(c.Context(), id, mode)
+	if err != nil {
+		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update self-trade prevention: "+err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Self-trade prevention updated successfully",
+		"mode":    mode,
+	})
+}
@@ -33,6 +33,7 @@ func (ar *AuthRouter) RegisterRoutes(router fiber.Router) {
 	authGroup.Get("/", auth.LoginMiddleware(auth.Config{Bypass: false}), ar.authTest)
 	authGroup.Post("/", ar.registerUser)
 	authGroup.Get("/api", auth.APIKeyMiddleware(auth.Config{Bypass: false}), ar.getAPIKeyDetails)
+	authGroup.Patch("/api/cancel-on-disconnect", auth.APIKeyMiddleware(auth.Config{Bypass: false}), ar.setCancelOnDisconnect)
 	authGroup.Post("/token", auth.LoginMiddleware(auth.Config{Bypass: false}), ar.generateTempAPIKey)
 }
 
@@ -122,17 +123,47 @@ func (ar *AuthRouter) getAPIKeyDetails(c *fiber.Ctx) error {
 
 	if apiKey != nil {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"status":    apiKey.Status,
-			"scopes":    postgresql.FilterTrueScopes(postgresql.APIScopeToMap(apiKey.Scopes)),
-			"createdAt": apiKey.CreatedAt,
-			"expiresAt": apiKey.ExpiresAt,
-			"time":      time.Now().Format(time.RFC3339),
+			"status":             apiKey.Status,
+			"scopes":             postgresql.FilterTrueScopes(postgresql.APIScopeToMap(apiKey.Scopes)),
+			"createdAt":          apiKey.CreatedAt,
+			"expiresAt":          apiKey.ExpiresAt,
+			"cancelOnDisconnect": apiKey.CancelOnDisconnect,
+			"time":               time.Now().Format(time.RFC3339),
 		})
 	} else {
 		return template.ErrorHandler(c, fiber.StatusUnauthorized, "Invalid or Expired API Key")
 	}
 }
 
+// @Summary		연결 종료 시 주문 취소 설정
+// @Description	요청에 사용한 API 키의 cancel-on-disconnect 설정을 변경합니다. 활성화하면 이 키로 연결한 마지막 /ws/notify 연결이 끊기고 유예 시간(CANCEL_ON_DISCONNECT_GRACE_SECONDS, 기본 10초) 안에 재접속하지 않을 경우 모든 미체결 주문(스탑 주문 포함)이 취소되며, 취소 알림은 재접속 시 전달됩니다.
+// @Tags			Auth
+// @Produce		json
+// @Param			Authorization	header		string	true	"Bearer {API_KEY}"
+// @Param			enabled			query		bool	true	"활성화 여부"
+// @Success		200				{object}	map[string]interface{}	"성공 시 변경된 설정 반환"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Router			/api/v1/auth/api/cancel-on-disconnect [patch]
+func (ar *AuthRouter) setCancelOnDisconnect(c *fiber.Ctx) error {
+	apiKey := c.Locals("apiKey").(*postgresql.APIKey)
+
+	enabled, err := strconv.ParseBool(c.Query("enabled"))
+	if err != nil {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "enabled must be true or false")
+	}
+
+	if err := postgresApp.Get().APIKeyRepo().SetCancelOnDisconnect(c.Context(), apiKey.ID, enabled); err != nil {
+		log.Println("Error updating cancel on disconnect:", err)
+		return template.ErrorHandler(c, fiber.StatusInternalServerError, "Failed to update cancel on disconnect")
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"cancelOnDisconnect": enabled,
+		"time":               time.Now().Format(time.RFC3339),
+	})
+}
+
 // @Summary		임시 API 키 생성
 // @Description	인증된 유저를 위해 새로운 API 키를 생성합니다. (임시 키, 24시간 유효)
 // @Tags			Auth
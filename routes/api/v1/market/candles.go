@@ -1,21 +1,208 @@
 package market
 
 import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/middlewares/auth"
+	s "PJS_Exchange/middlewares/symbol"
+	pb "PJS_Exchange/protobuf/candles"
+	"PJS_Exchange/routes/ws"
 	"PJS_Exchange/template"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/proto"
 )
 
+const (
+	MIMEApplicationProtobuf = "application/x-protobuf"
+
+	defaultCandleLimit = 500
+	maxCandleLimit     = 1000
+)
+
+var candleIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"1d":  24 * time.Hour,
+}
+
 type CandlesRouter struct{}
 
 func (cr *CandlesRouter) RegisterRoutes(router fiber.Router) {
 	candlesGroup := router.Group("/candles")
 
-	candlesGroup.Get("/:sym", cr.getCandles)
+	candlesGroup.Use(auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+		MarketHistoryRead: true,
+	}))
+
+	candlesGroup.Get("/:sym", s.IsViewable(), cr.getCandles)
 }
 
-// TODO 추후 protobuf로 변경
+// === 핸들러 함수들 ===
+
+// @Summary		봉 데이터 조회
+// @Description	체결 내역을 봉(OHLCV) 단위로 집계하여 반환합니다. Accept 헤더가 application/x-protobuf 인 경우 protobuf(Candles)로 반환합니다.
+// @Tags			Market - Candles
+// @Produce		json
+// @Produce		application/x-protobuf
+// @Param			symbol			path		string				true	"심볼 (예: NVDA)"
+// @Param			interval		query		string				false	"봉 간격 (1m, 5m, 15m, 1h, 1d / 기본값 1m)"
+// @Param			from			query		int					false	"시작 시각 (Unix milli, 이상)"
+// @Param			to				query		int					false	"종료 시각 (Unix milli, 미만 / 기본값 현재)"
+// @Param			limit			query		int					false	"최대 봉 개수 (기본값 500, 최대 1000 / 최근 봉부터)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Success		200				{object}	map[string]interface{}	"성공 시 봉 데이터 반환"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Router			/api/v1/market/candles/{symbol} [get]
 func (cr *CandlesRouter) getCandles(c *fiber.Ctx) error {
-	symbol := c.Params("sym")
-	return template.ErrorHandler(c, fiber.StatusNotImplemented, "Not implemented: GET /candles/"+symbol)
+	symbol := c.Locals("symbolData").(*postgresql.Symbol).Symbol
+
+	intervalParam := c.Query("interval", "1m")
+	interval, ok := candleIntervals[intervalParam]
+	if !ok {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid interval. Use one of 1m, 5m, 15m, 1h, 1d")
+	}
+
+	from, err := strconv.ParseInt(c.Query("from", "0"), 10, 64)
+	if err != nil || from < 0 {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid from timestamp")
+	}
+	to, err := strconv.ParseInt(c.Query("to", strconv.FormatInt(time.Now().UnixMilli()+1, 10)), 10, 64)
+	if err != nil || to <= from {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid to timestamp")
+	}
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultCandleLimit)))
+	if err != nil || limit <= 0 || limit > maxCandleLimit {
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid limit. Must be between 1 and "+strconv.Itoa(maxCandleLimit))
+	}
+
+	// 일봉은 거래소 현지 자정 기준으로 나눔
+	var offset time.Duration
+	if ex, err := exchanges.Load(); err == nil {
+		offset = time.Duration(ex.DefaultUTCOffset) * time.Hour
+	}
+
+	// 임시 원장에 있는 체결은 원장에서, 그 이전 체결은 DB에서 집계 (아직 저장되지 않은 최근 체결 포함)
+	var trades []template.Ledger
+	if ledger := ws.GetTempLedger(symbol); ledger != nil {
+		trades = ledger.GetRange(0, ledger.Size())
+	}
+	dbTo := to
+	if len(trades) != 0 {
+		dbTo = min(to, trades[0].Timestamp)
+	}
+
+	var candles []postgresql.Candle
+	if from < dbTo {
+		candles, err = postgresApp.Get().TradeRepo().GetCandles(c.Context(), symbol, interval, offset,
+			time.UnixMilli(from), time.UnixMilli(dbTo), limit)
+		if err != nil {
+			return template.ErrorHandler(c, fiber.StatusInternalServerError, "Error fetching candles")
+		}
+	}
+	if dbTo < to {
+		candles = mergeCandles(candles, aggregateLedger(trades, interval, offset, max(from, dbTo), to))
+	}
+	if len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+	if candles == nil {
+		candles = []postgresql.Candle{}
+	}
+
+	if c.Accepts(fiber.MIMEApplicationJSON, MIMEApplicationProtobuf) == MIMEApplicationProtobuf {
+		message := &pb.Candles{
+			Symbol:   symbol,
+			Interval: intervalParam,
+			Candles:  make([]*pb.Candle, 0, len(candles)),
+		}
+		for _, candle := range candles {
+			message.Candles = append(message.Candles, &pb.Candle{
+				Timestamp: candle.Timestamp,
+				Open:      candle.Open,
+				High:      candle.High,
+				Low:       candle.Low,
+				Close:     candle.Close,
+				Volume:    candle.Volume,
+			})
+		}
+		data, err := proto.Marshal(message)
+		if err != nil {
+			return template.ErrorHandler(c, fiber.StatusInternalServerError, "Error encoding candles")
+		}
+		c.Set(fiber.HeaderContentType, MIMEApplicationProtobuf)
+		return c.Status(fiber.StatusOK).Send(data)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"symbol":   symbol,
+		"interval": intervalParam,
+		"candles":  candles,
+	})
+}
+
+// aggregateLedger 임시 원장의 체결 중 from 이상 to 미만인 체결을 봉 단위로 집계
+func aggregateLedger(trades []template.Ledger, interval, offset time.Duration, from, to int64) []postgresql.Candle {
+	// 원장은 시간순으로 쌓이므로 이진 탐색으로 시작 위치 찾기
+	start := sort.Search(len(trades), func(i int) bool {
+		return trades[i].Timestamp >= from
+	})
+
+	intervalMs := interval.Milliseconds()
+	offsetMs := offset.Milliseconds()
+
+	var candles []postgresql.Candle
+	for _, trade := range trades[start:] {
+		if trade.Timestamp >= to {
+			break
+		}
+		price := int64(trade.Price)
+		bucket := (trade.Timestamp+offsetMs)/intervalMs*intervalMs - offsetMs
+		if n := len(candles); n != 0 && candles[n-1].Timestamp == bucket {
+			last := &candles[n-1]
+			last.High = max(last.High, price)
+			last.Low = min(last.Low, price)
+			last.Close = price
+			last.Volume += int64(trade.Volume)
+			continue
+		}
+		candles = append(candles, postgresql.Candle{
+			Timestamp: bucket,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			Volume:    int64(trade.Volume),
+		})
+	}
+	return candles
+}
+
+// mergeCandles DB에서 집계한 봉 뒤에 원장에서 집계한 봉을 이어 붙임 (경계의 같은 봉은 합침)
+func mergeCandles(older, newer []postgresql.Candle) []postgresql.Candle {
+	if len(older) == 0 {
+		return newer
+	}
+	if len(newer) == 0 {
+		return older
+	}
+
+	last := &older[len(older)-1]
+	if last.Timestamp == newer[0].Timestamp {
+		last.High = max(last.High, newer[0].High)
+		last.Low = min(last.Low, newer[0].Low)
+		last.Close = newer[0].Close
+		last.Volume += newer[0].Volume
+		newer = newer[1:]
+	}
+	return append(older, newer...)
 }
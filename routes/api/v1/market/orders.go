@@ -1,12 +1,18 @@
 package market
 
 import (
+	"PJS_Exchange/app/postgresApp"
 	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
 	"PJS_Exchange/exchanges/channels"
 	"PJS_Exchange/middlewares/auth"
+	"PJS_Exchange/middlewares/idempotency"
 	"PJS_Exchange/middlewares/session"
 	s "PJS_Exchange/middlewares/symbol"
 	t "PJS_Exchange/template"
+	"PJS_Exchange/utils"
+	"context"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,73 +24,340 @@ type OrdersRouter struct{}
 func (or *OrdersRouter) RegisterRoutes(router fiber.Router) {
 	ordersGroup := router.Group("/orders")
 
+	ordersGroup.Get("/",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderRead: true,
+		}), or.getAllOrders)
+	ordersGroup.Delete("/",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderCancel: true,
+		}), idempotency.New(), session.IsOnline(), or.cancelAllOrders)
+	ordersGroup.Post("/batch",
+		auth.APIKeyMiddleware(auth.Config{Bypass: false}), idempotency.New(), or.batchOrders)
+	ordersGroup.Get("/history",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderRead: true,
+		}), or.getOrderHistory)
+	ordersGroup.Get("/fills",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderRead: true,
+		}), or.getFills)
+	ordersGroup.Get("/client/:cid",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderRead: true,
+		}), or.getClientOrder)
 	ordersGroup.Get("/:sym",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderRead: true,
-		}), session.IsOnline(), s.IsTradable(), or.getOrders)
+		}), s.IsViewable(), or.getOrders)
+	ordersGroup.Delete("/:sym",
+		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
+			OrderCancel: true,
+		}), idempotency.New(), session.IsOnline(), s.IsViewable(), or.cancelSymbolOrders)
 	ordersGroup.Post("/:sym/buy",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderCreate: true,
-		}), session.IsOnline(), s.IsTradable(), or.buyOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.buyOrder)
 	ordersGroup.Patch("/:sym/buy",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderModify: true,
-		}), session.IsOnline(), s.IsTradable(), or.modifyBuyOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.modifyBuyOrder)
 	ordersGroup.Delete("/:sym/buy",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderCancel: true,
-		}), session.IsOnline(), s.IsTradable(), or.cancelBuyOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.cancelBuyOrder)
 	ordersGroup.Post("/:sym/sell",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderCreate: true,
-		}), session.IsOnline(), s.IsTradable(), or.sellOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.sellOrder)
 	ordersGroup.Patch("/:sym/sell",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderModify: true,
-		}), session.IsOnline(), s.IsTradable(), or.modifySellOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.modifySellOrder)
 	ordersGroup.Delete("/:sym/sell",
 		auth.APIKeyMiddlewareRequireScopes(auth.Config{Bypass: false}, postgresql.APIKeyScope{
 			OrderCancel: true,
-		}), session.IsOnline(), s.IsTradable(), or.cancelSellOrder)
+		}), idempotency.New(), session.IsOnline(), s.IsTradable(), or.cancelSellOrder)
 }
 
-// @Summary 주문 조회
-// @Description 사용자의 모든 주문을 조회합니다.
+// @Summary 미체결 주문 조회
+// @Description 특정 심볼에서 호가에 남아있는 사용자의 주문을 접수 시각 순으로 조회합니다.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
-// @Success		200				{object}	map[string]interface{}	"사용자의 모든 주문 정보"
-// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Success		200				{object}	map[string][]template.OpenOrder	"사용자의 미체결 주문 목록"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
 // @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
-// @Failure		503				{object}	map[string]string	"장이 닫혔을 때 에러 메시지 반환"
 // @Router			/api/v1/market/orders/{symbol} [get]
 func (or *OrdersRouter) getOrders(c *fiber.Ctx) error {
 	symbol := c.Params("sym")
 	user := c.Locals("user").(*postgresql.User)
 
+	orders := channels.OP.GetOpenOrders(symbol, user.ID)
+	if orders == nil {
+		orders = []t.OpenOrder{}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"symbol": symbol,
-		"orders": user.Username,
+		"orders": orders,
+	})
+}
+
+// @Summary 전체 미체결 주문 조회
+// @Description 모든 심볼에서 호가에 남아있는 사용자의 주문을 접수 시각 순으로 조회합니다.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Success		200				{object}	map[string][]template.OpenOrder	"사용자의 미체결 주문 목록"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Router			/api/v1/market/orders [get]
+func (or *OrdersRouter) getAllOrders(c *fiber.Ctx) error {
+	user := c.Locals("user").(*postgresql.User)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"orders": channels.OP.GetAllOpenOrders(user.ID),
+	})
+}
+
+// @Summary client_order_id 주문 조회
+// @Description 오늘 사용한 client_order_id 로 주문을 찾습니다. 주문이 아직 호가에 남아있거나 발동 대기 중이면 order에 미체결 주문 정보가 포함되며, 끝난 주문은 /orders/history?client_order_id= 로 내역을 조회할 수 있습니다.
+// @Tags Orders
+// @Produce json
+// @Param			cid				path		string				true	"client_order_id"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Success		200				{object}	map[string]interface{}	"심볼, 주문 ID 및 미체결 주문 정보"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		404				{object}	map[string]string	"오늘 사용하지 않은 client_order_id 인 경우 에러 메시지 반환"
+// @Router			/api/v1/market/orders/client/{cid} [get]
+func (or *OrdersRouter) getClientOrder(c *fiber.Ctx) error {
+	clientOrderID := c.Params("cid")
+	user := c.Locals("user").(*postgresql.User)
+
+	order, ok := channels.LookupClientOrder(user.ID, clientOrderID)
+	if !ok {
+		return t.ErrorHandler(c, fiber.StatusNotFound, "ClientOrderID '"+clientOrderID+"' not found")
+	}
+
+	// 미체결 주문이면 현재 상태 포함 (끝난 주문은 nil)
+	var openOrder *t.OpenOrder
+	for _, o := range channels.OP.GetOpenOrders(order.Symbol, user.ID) {
+		if o.OrderID == order.OrderID {
+			openOrder = &o
+			break
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"clientOrderID": clientOrderID,
+		"symbol":        order.Symbol,
+		"orderID":       order.OrderID,
+		"open":          openOrder != nil,
+		"order":         openOrder,
+	})
+}
+
+// @Summary 전체 주문 일괄 취소
+// @Description 모든 심볼에서 사용자의 미체결 주문(발동 대기 중인 스탑 주문 포함)을 한 번에 취소합니다. side를 지정하면 해당 방향의 주문만 취소합니다. 심볼별로 매칭 엔진 안에서 한 번에 처리되며, 취소된 주문마다 /ws/notify 로 취소 알림이 전송됩니다.
+// @Tags Orders
+// @Produce json
+// @Param			side			query		string				false	"buy or sell (생략 시 모두)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
+// @Success		200				{object}	map[string]interface{}	"취소된 주문 ID 목록"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		503				{object}	map[string]string	"장이 닫혔을 때 에러 메시지 반환"
+// @Router			/api/v1/market/orders [delete]
+func (or *OrdersRouter) cancelAllOrders(c *fiber.Ctx) error {
+	side := c.Query("side")
+	if side != "" && side != t.SideBuy && side != t.SideSell {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid side value")
+	}
+	user := c.Locals("user").(*postgresql.User)
+
+	canceled := channels.OP.CancelOrders("", user.ID, side, "Canceled by user (mass cancel)")
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Orders canceled successfully",
+		"canceled": canceled,
+		"count":    len(canceled),
+	})
+}
+
+// @Summary 심볼 주문 일괄 취소
+// @Description 특정 심볼에서 사용자의 미체결 주문(발동 대기 중인 스탑 주문 포함)을 매칭 엔진 안에서 한 번에 취소합니다. side를 지정하면 해당 방향의 주문만 취소합니다. 취소된 주문마다 /ws/notify 로 취소 알림이 전송됩니다.
+// @Tags Orders
+// @Produce json
+// @Param			symbol			path		string				true	"심볼 (예: NVDA)"
+// @Param			side			query		string				false	"buy or sell (생략 시 모두)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
+// @Success		200				{object}	map[string]interface{}	"취소된 주문 ID 목록"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
+// @Failure		503				{object}	map[string]string	"장이 닫혔을 때 에러 메시지 반환"
+// @Router			/api/v1/market/orders/{symbol} [delete]
+func (or *OrdersRouter) cancelSymbolOrders(c *fiber.Ctx) error {
+	symbol := c.Params("sym")
+	side := c.Query("side")
+	if side != "" && side != t.SideBuy && side != t.SideSell {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid side value")
+	}
+	user := c.Locals("user").(*postgresql.User)
+
+	canceled := channels.OP.CancelOrders(symbol, user.ID, side, "Canceled by user (mass cancel)")
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Orders canceled successfully",
+		"symbol":   symbol,
+		"canceled": canceled,
+		"count":    len(canceled),
 	})
 }
 
+// @Summary 주문 내역 조회
+// @Description 저장된 주문 이벤트(접수, 정정, 체결, 취소 등)를 시간순으로 조회합니다.
+// @Tags Orders
+// @Produce json
+// @Param			symbol			query		string				false	"심볼 (예: NVDA)"
+// @Param			side			query		string				false	"buy or sell"
+// @Param			status			query		string				false	"open, partially_filled, filled, canceled, rejected"
+// @Param			client_order_id	query		string				false	"client_order_id"
+// @Param			from			query		int					false	"시작 시각 (Unix milli, 이상)"
+// @Param			to				query		int					false	"종료 시각 (Unix milli, 미만)"
+// @Param			page			query		int					false	"페이지 (기본값 1)"
+// @Param			limit			query		int					false	"페이지당 개수 (기본값 100, 최대 1000)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Success		200				{object}	map[string]interface{}	"주문 이벤트 목록"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Router			/api/v1/market/orders/history [get]
+func (or *OrdersRouter) getOrderHistory(c *fiber.Ctx) error {
+	filter, page, errMsg := parseHistoryFilter(c)
+	if errMsg != "" {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, errMsg)
+	}
+
+	events, err := postgresApp.Get().OrderEventRepo().GetOrderEvents(c.Context(), filter)
+	if err != nil {
+		return t.ErrorHandler(c, fiber.StatusInternalServerError, "Error fetching order history")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"page":   page,
+		"limit":  filter.Limit,
+		"orders": events,
+	})
+}
+
+// @Summary 체결 내역 조회
+// @Description 저장된 사용자의 체결 내역을 시간순으로 조회합니다.
+// @Tags Orders
+// @Produce json
+// @Param			symbol			query		string				false	"심볼 (예: NVDA)"
+// @Param			side			query		string				false	"buy or sell"
+// @Param			from			query		int					false	"시작 시각 (Unix milli, 이상)"
+// @Param			to				query		int					false	"종료 시각 (Unix milli, 미만)"
+// @Param			page			query		int					false	"페이지 (기본값 1)"
+// @Param			limit			query		int					false	"페이지당 개수 (기본값 100, 최대 1000)"
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Success		200				{object}	map[string]interface{}	"체결 내역 목록"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
+// @Router			/api/v1/market/orders/fills [get]
+func (or *OrdersRouter) getFills(c *fiber.Ctx) error {
+	filter, page, errMsg := parseHistoryFilter(c)
+	if errMsg != "" {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, errMsg)
+	}
+
+	fills, err := postgresApp.Get().TradeRepo().GetFills(c.Context(), filter)
+	if err != nil {
+		return t.ErrorHandler(c, fiber.StatusInternalServerError, "Error fetching fills")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"page":  page,
+		"limit": filter.Limit,
+		"fills": fills,
+	})
+}
+
+// parseHistoryFilter 주문 내역 / 체결 내역 조회 조건 파싱 (실패 시 에러 메시지 반환)
+func parseHistoryFilter(c *fiber.Ctx) (postgresql.HistoryFilter, int, string) {
+	filter := postgresql.HistoryFilter{
+		UserID: c.Locals("user").(*postgresql.User).ID,
+		Symbol: c.Query("symbol"),
+		Side:   c.Query("side"),
+		Status: c.Query("status"),
+
+		ClientOrderID: c.Query("client_order_id"),
+	}
+
+	if filter.Side != "" && filter.Side != t.SideBuy && filter.Side != t.SideSell {
+		return filter, 0, "Invalid side"
+	}
+	switch filter.Status {
+	case "", t.StatusOpen, t.StatusModified, t.StatusPartiallyFilled, t.StatusFilled, t.StatusCanceled, t.StatusRejected:
+	default:
+		return filter, 0, "Invalid status"
+	}
+
+	if from := c.Query("from"); from != "" {
+		ms, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return filter, 0, "Invalid from timestamp"
+		}
+		fromTime := time.UnixMilli(ms)
+		filter.From = &fromTime
+	}
+	if to := c.Query("to"); to != "" {
+		ms, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return filter, 0, "Invalid to timestamp"
+		}
+		toTime := time.UnixMilli(ms)
+		filter.To = &toTime
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page <= 0 {
+		return filter, 0, "Invalid page"
+	}
+	limit, err := strconv.Atoi(c.Query("limit", "100"))
+	if err != nil || limit <= 0 || limit > 1000 {
+		return filter, 0, "Invalid limit. Must be between 1 and 1000"
+	}
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	return filter, page, ""
+}
+
 /// Buy Orders
 
 // TODO: 추후 protobuf로 변경
 // @Summary 매수 주문
-// @Description 지정가, 시장가 주문을 접수합니다.
+// @Description 지정가, 시장가, 스탑(stop), 스탑 지정가(stop_limit) 주문을 접수합니다. 스탑 주문은 최종 체결가가 stop_price에 도달하면(매수는 이상, 매도는 이하) 시장가 / 지정가 주문으로 발동되며, 발동 전까지는 호가에 올라가지 않습니다. time_in_force로 유효 기간을 지정할 수 있습니다. (지정가 DAY(기본값, 장 종료 시 만료), GTC, GTD(expire_at 까지), IOC, FOK / 시장가 IOC(기본값), FOK) 만료된 주문은 /ws/notify 로 취소 알림이 전송됩니다. 지정가 주문에 post_only를 지정하면 즉시 체결되지 않도록 반대 호가와 겹치는 경우 거절(reject)하거나 한 호가 단위 물러난 가격으로 조정(reprice)합니다. 지정가 주문에 display_quantity를 지정하면 아이스버그 주문으로 해당 수량만 호가에 노출되고, 노출 수량이 모두 체결될 때마다 숨겨진 수량에서 다시 채워집니다. (다시 채워진 수량은 같은 가격대의 맨 뒤로 이동) 프리장, 포스트장은 단일가 매매로 주문을 모아두었다가 정규장 시작(시가), 장 종료(종가) 시 체결 수량이 가장 많은 한 가격으로 체결하며, 단일가 매매 중에는 시장가, IOC, FOK 주문을 받지 않습니다. (예상 체결가는 /ws/depth 로 전송) 가격은 거래소 통화 최소 단위의 정수입니다. (exchange-data의 price_precision 참고, 예: USD 12.34 -> 1234) 가격이 호가 단위의 배수가 아니거나 일일 상한가 / 하한가를 벗어나거나 수량이 최소 주문 수량 미만이면 거절됩니다. 체결 가격이 직전 체결가 대비 변동성 완화장치 기준을 벗어나면 체결을 멈추고 일정 시간 동안 단일가 매매로 전환합니다. (이 동안 취소 외 주문 접수 불가) self_trade_prevention을 지정하면 내 주문끼리 체결되려 할 때 체결하지 않고 들어온 주문 취소(cancel_newest), 호가에 있던 주문 취소(cancel_oldest), 둘 다 취소(cancel_both), 겹치는 수량만큼 둘 다 감소(decrement)로 처리하며 /ws/notify 로 알림이 전송됩니다. (생략 시 사용자 기본값, none 이면 사용 안함, 단일가 체결에는 적용되지 않음) client_order_id를 지정하면 실행 보고서와 미체결 주문에 함께 표시되고 정정, 취소, 조회에 order_id 대신 사용할 수 있습니다. client_order_id는 사용자별로 하루 동안 고유해야 하며 (거절된 주문 포함) 이미 사용한 ID면 409와 함께 기존 주문 ID를 반환하므로, 응답을 받지 못한 주문을 같은 client_order_id로 다시 보내도 중복 접수되지 않습니다. (reason_code: invalid_client_order_id, duplicate_client_order_id, invalid_tick_size, below_minimum_quantity, invalid_time_in_force, invalid_expire_time, invalid_post_only, post_only_would_cross, auction_in_progress, price_out_of_limit, invalid_self_trade_prevention, market_halted)
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.CreateOrderRequest		true	"주문 정보"
 // @Success		201				{object}	map[string]string	"주문이 성공적으로 접수되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		409				{object}	map[string]string	"이미 사용한 client_order_id 또는 Idempotency-Key 요청이 처리 중인 경우 에러 메시지 반환"
+// @Failure		422				{object}	map[string]string	"Idempotency-Key 를 다른 요청에 사용한 경우 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
 // @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환"
@@ -99,44 +372,60 @@ func (or *OrdersRouter) buyOrder(c *fiber.Ctx) error {
 	}
 
 	// 서버 측에서 설정
-	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
+	user := c.Locals("user").(*postgresql.User)
+	orderRequest.Timestamp = 0
+	orderRequest.UserID = user.ID
 	orderRequest.OrderID = uuid.NewString()
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideBuy
 	orderRequest.Status = t.StatusOpen
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// 자기매매 방지 모드를 지정하지 않으면 사용자 기본값 사용
+	if orderRequest.SelfTradePrevention == "" {
+		orderRequest.SelfTradePrevention = user.SelfTradePrevention
+	}
+
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
 	case <-time.After(5 * time.Second):
+		channels.ReleaseClientOrder(&orderRequest) // 매칭 엔진에 전달되지 않았으므로 다시 사용 가능
 		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
 	}
 
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to place buy order: "+result.Message)
+			return orderRejected(c, result, "Failed to place buy order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "Buy order placed successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Buy order placed successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
 	})
 }
 
 // @Summary 매수 주문 수정
-// @Description 기존 매수 주문을 수정합니다.
+// @Description 기존 매수 주문을 수정합니다. 발동 전인 스탑 주문은 stop_price도 수정할 수 있습니다. order_id 대신 오늘 접수한 주문의 client_order_id로 지정할 수 있습니다.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.ModifyOrderRequest	true	"수정할 주문 정보"
 // @Success		200				{object}	map[string]string	"주문이 성공적으로 수정되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
@@ -151,12 +440,18 @@ func (or *OrdersRouter) modifyBuyOrder(c *fiber.Ctx) error {
 		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
+	orderRequest.Timestamp = 0
 	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideBuy
 	orderRequest.Status = t.StatusModified
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
@@ -167,27 +462,30 @@ func (or *OrdersRouter) modifyBuyOrder(c *fiber.Ctx) error {
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to modify buy order: "+result.Message)
+			return orderRejected(c, result, "Failed to modify buy order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Modify buy order successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Modify buy order successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
 	})
 }
 
 // @Summary 매수 주문 취소
-// @Description 기존 매수 주문을 취소합니다.
+// @Description 기존 매수 주문을 취소합니다. order_id 대신 오늘 접수한 주문의 client_order_id로 지정할 수 있습니다.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.CancelOrderRequest	true	"취소할 주문 정보"
 // @Success		200				{object}	map[string]string	"주문이 성공적으로 취소되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
@@ -202,12 +500,18 @@ func (or *OrdersRouter) cancelBuyOrder(c *fiber.Ctx) error {
 		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
+	orderRequest.Timestamp = 0
 	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideBuy
 	orderRequest.Status = t.StatusCanceled
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
@@ -218,30 +522,35 @@ func (or *OrdersRouter) cancelBuyOrder(c *fiber.Ctx) error {
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to cancel buy order: "+result.Message)
+			return orderRejected(c, result, "Failed to cancel buy order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Cancel buy order successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Cancel buy order successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
 	})
 }
 
 /// Sell Orders
 
 // @Summary 매도 주문
-// @Description 지정가, 시장가 주문을 접수합니다.
+// @Description 지정가, 시장가, 스탑(stop), 스탑 지정가(stop_limit) 주문을 접수합니다. 스탑 주문은 최종 체결가가 stop_price에 도달하면(매수는 이상, 매도는 이하) 시장가 / 지정가 주문으로 발동되며, 발동 전까지는 호가에 올라가지 않습니다. time_in_force로 유효 기간을 지정할 수 있습니다. (지정가 DAY(기본값, 장 종료 시 만료), GTC, GTD(expire_at 까지), IOC, FOK / 시장가 IOC(기본값), FOK) 만료된 주문은 /ws/notify 로 취소 알림이 전송됩니다. 지정가 주문에 post_only를 지정하면 즉시 체결되지 않도록 반대 호가와 겹치는 경우 거절(reject)하거나 한 호가 단위 물러난 가격으로 조정(reprice)합니다. 지정가 주문에 display_quantity를 지정하면 아이스버그 주문으로 해당 수량만 호가에 노출되고, 노출 수량이 모두 체결될 때마다 숨겨진 수량에서 다시 채워집니다. (다시 채워진 수량은 같은 가격대의 맨 뒤로 이동) 프리장, 포스트장은 단일가 매매로 주문을 모아두었다가 정규장 시작(시가), 장 종료(종가) 시 체결 수량이 가장 많은 한 가격으로 체결하며, 단일가 매매 중에는 시장가, IOC, FOK 주문을 받지 않습니다. (예상 체결가는 /ws/depth 로 전송) 가격은 거래소 통화 최소 단위의 정수입니다. (exchange-data의 price_precision 참고, 예: USD 12.34 -> 1234) 가격이 호가 단위의 배수가 아니거나 일일 상한가 / 하한가를 벗어나거나 수량이 최소 주문 수량 미만이면 거절됩니다. 체결 가격이 직전 체결가 대비 변동성 완화장치 기준을 벗어나면 체결을 멈추고 일정 시간 동안 단일가 매매로 전환합니다. (이 동안 취소 외 주문 접수 불가) self_trade_prevention을 지정하면 내 주문끼리 체결되려 할 때 체결하지 않고 들어온 주문 취소(cancel_newest), 호가에 있던 주문 취소(cancel_oldest), 둘 다 취소(cancel_both), 겹치는 수량만큼 둘 다 감소(decrement)로 처리하며 /ws/notify 로 알림이 전송됩니다. (생략 시 사용자 기본값, none 이면 사용 안함, 단일가 체결에는 적용되지 않음) client_order_id를 지정하면 실행 보고서와 미체결 주문에 함께 표시되고 정정, 취소, 조회에 order_id 대신 사용할 수 있습니다. client_order_id는 사용자별로 하루 동안 고유해야 하며 (거절된 주문 포함) 이미 사용한 ID면 409와 함께 기존 주문 ID를 반환하므로, 응답을 받지 못한 주문을 같은 client_order_id로 다시 보내도 중복 접수되지 않습니다. (reason_code: invalid_client_order_id, duplicate_client_order_id, invalid_tick_size, below_minimum_quantity, invalid_time_in_force, invalid_expire_time, invalid_post_only, post_only_would_cross, auction_in_progress, price_out_of_limit, invalid_self_trade_prevention, market_halted)
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.CreateOrderRequest		true	"주문 정보"
 // @Success		201				{object}	map[string]string	"주문이 성공적으로 접수되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		409				{object}	map[string]string	"이미 사용한 client_order_id 또는 Idempotency-Key 요청이 처리 중인 경우 에러 메시지 반환"
+// @Failure		422				{object}	map[string]string	"Idempotency-Key 를 다른 요청에 사용한 경우 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
 // @Failure		500				{object}	map[string]string	"서버 오류 발생 시 에러 메시지 반환
@@ -256,44 +565,60 @@ func (or *OrdersRouter) sellOrder(c *fiber.Ctx) error {
 	}
 
 	// 서버 측에서 설정
-	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
+	user := c.Locals("user").(*postgresql.User)
+	orderRequest.Timestamp = 0
+	orderRequest.UserID = user.ID
 	orderRequest.OrderID = uuid.NewString()
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideSell
 	orderRequest.Status = t.StatusOpen
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// 자기매매 방지 모드를 지정하지 않으면 사용자 기본값 사용
+	if orderRequest.SelfTradePrevention == "" {
+		orderRequest.SelfTradePrevention = user.SelfTradePrevention
+	}
+
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
 	case <-time.After(5 * time.Second):
+		channels.ReleaseClientOrder(&orderRequest) // 매칭 엔진에 전달되지 않았으므로 다시 사용 가능
 		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
 	}
 
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to place sell order: "+result.Message)
+			return orderRejected(c, result, "Failed to place sell order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "Sell order placed successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Sell order placed successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
 	})
 }
 
 // @Summary 매도 주문 수정
-// @Description 기존 매도 주문을 수정합니다.
+// @Description 기존 매도 주문을 수정합니다. 발동 전인 스탑 주문은 stop_price도 수정할 수 있습니다. order_id 대신 오늘 접수한 주문의 client_order_id로 지정할 수 있습니다.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.ModifyOrderRequest	true	"수정할 주문 정보"
 // @Success		200				{object}	map[string]string	"주문이 성공적으로 수정되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
@@ -309,12 +634,18 @@ func (or *OrdersRouter) modifySellOrder(c *fiber.Ctx) error {
 	}
 
 	// 서버 측에서 설정
+	orderRequest.Timestamp = 0
 	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideSell
 	orderRequest.Status = t.StatusModified
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
@@ -325,27 +656,30 @@ func (or *OrdersRouter) modifySellOrder(c *fiber.Ctx) error {
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to modify sell order")
+			return orderRejected(c, result, "Failed to modify sell order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Modify sell order successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Modify sell order successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
 	})
 }
 
 // @Summary 매도 주문 취소
-// @Description 기존 매도 주문을 취소합니다.
+// @Description 기존 매도 주문을 취소합니다. order_id 대신 오늘 접수한 주문의 client_order_id로 지정할 수 있습니다.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param			symbol			path		string				true	"심볼 (예: NVDA)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
 // @Param			order			body		template.CancelOrderRequest	true	"취소할 주문 정보"
 // @Success		200				{object}	map[string]string	"주문이 성공적으로 취소되었음을 알리는 메시지"
+// @Success		202				{object}	map[string]string	"매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 경우 (결과는 /ws/notify 로 확인)"
 // @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
 // @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
 // @Failure		404				{object}	map[string]string	"심볼을 찾을 수 없을 때 에러 메시지 반환"
@@ -360,12 +694,18 @@ func (or *OrdersRouter) cancelSellOrder(c *fiber.Ctx) error {
 		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid request body")
 	}
 
+	orderRequest.Timestamp = 0
 	orderRequest.UserID = c.Locals("user").(*postgresql.User).ID
 	orderRequest.Symbol = symbol
 	orderRequest.Side = t.SideSell
 	orderRequest.Status = t.StatusCanceled
 	orderRequest.ResultChan = make(chan t.Result, 1)
 
+	// client_order_id 처리 (신규 주문은 오늘 사용한 ID인지 확인, 정정 / 취소는 order_id 로 변환)
+	if code, reasonCode, message, existing := channels.PrepareClientOrder(&orderRequest); code != 0 {
+		return clientOrderRejected(c, code, reasonCode, message, existing)
+	}
+
 	// 주문 처리
 	select {
 	case channels.OP.OrderRequestChan <- orderRequest:
@@ -376,14 +716,140 @@ func (or *OrdersRouter) cancelSellOrder(c *fiber.Ctx) error {
 	select {
 	case result := <-orderRequest.ResultChan:
 		if !result.Success {
-			return t.ErrorHandler(c, result.Code, "Failed to cancel sell order")
+			return orderRejected(c, result, "Failed to cancel sell order")
 		}
 	case <-time.After(5 * time.Second):
-		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Order processing is busy, please try again later")
+		return orderPending(c, orderRequest)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Cancel sell order successfully",
-		"orderID": orderRequest.OrderID,
+		"message":       "Cancel sell order successfully",
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
+	})
+}
+
+/// Batch Orders
+
+// batchOrderLimit 일괄 주문 한 번에 보낼 수 있는 최대 요청 수
+func batchOrderLimit() int {
+	limit, err := strconv.Atoi(utils.GetEnv("BATCH_ORDER_LIMIT", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	return limit
+}
+
+// @Summary 일괄 주문
+// @Description 여러 심볼의 신규 주문(new), 정정(modify), 취소(cancel) 요청을 한 번에 접수합니다. 인증은 한 번만 하고 심볼 상태는 심볼마다 한 번만 확인하며, 각 요청은 순서대로 매칭 엔진에 전달된 후 요청 순서와 같은 순서로 개별 결과를 반환합니다. (같은 심볼의 요청은 보낸 순서대로 처리되고, 다른 심볼의 요청은 동시에 처리됨) 각 요청의 필드는 단일 주문 API와 같으며, 요청마다 API 키에 해당 권한(order:create, order:modify, order:cancel)이 있어야 합니다. 일부 요청이 실패해도 나머지 요청은 처리되므로 results의 success와 code를 확인해야 합니다. 최대 요청 수는 BATCH_ORDER_LIMIT(기본값 20)입니다. 거래 중단 중에는 취소만 처리됩니다.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @Param			Idempotency-Key	header		string				false	"같은 키로 다시 요청하면 처리하지 않고 처음 응답 반환 (24시간, Idempotent-Replayed 헤더 포함)"
+// @Param			orders			body		template.BatchOrderRequest	true	"일괄 주문 정보"
+// @Success		200				{object}	map[string][]template.BatchOrderResult	"요청 순서대로 개별 처리 결과"
+// @Failure		400				{object}	map[string]string	"잘못된 요청 시 에러 메시지 반환"
+// @Failure		401				{object}	map[string]string	"인증 실패 시 에러 메시지 반환"
+// @Failure		409				{object}	map[string]string	"Idempotency-Key 요청이 처리 중인 경우 에러 메시지 반환"
+// @Failure		422				{object}	map[string]string	"Idempotency-Key 를 다른 요청에 사용한 경우 에러 메시지 반환"
+// @Failure		503				{object}	map[string]string	"장이 닫혔을 때 에러 메시지 반환"
+// @Router			/api/v1/market/orders/batch [post]
+func (or *OrdersRouter) batchOrders(c *fiber.Ctx) error {
+	var batch t.BatchOrderRequest
+	if err := c.BodyParser(&batch); err != nil {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, "Invalid request body")
+	}
+	limit := batchOrderLimit()
+	if len(batch.Orders) == 0 || len(batch.Orders) > limit {
+		return t.ErrorHandler(c, fiber.StatusBadRequest, "Batch must contain between 1 and "+strconv.Itoa(limit)+" orders")
+	}
+	if exchanges.MarketStatus == "closed" {
+		return t.ErrorHandler(c, fiber.StatusServiceUnavailable, "Market is closed")
+	}
+
+	user := c.Locals("user").(*postgresql.User)
+	apiKey := c.Locals("apiKey").(*postgresql.APIKey)
+
+	// 순서대로 매칭 엔진에 전달 (심볼 상태는 심볼마다 한 번만 확인)
+	checked := make(map[string]channels.TradableCheck)
+	results := make([]t.BatchOrderResult, len(batch.Orders))
+	requests := make([]*t.OrderRequest, len(batch.Orders)) // 매칭 엔진에 전달된 요청 (전달 전에 거절되면 nil)
+	for i, item := range batch.Orders {
+		orderRequest, result, ok := channels.OP.SubmitItem(c.Context(), item, user, apiKey, checked)
+		results[i] = t.BatchOrderResult{
+			Index:         i,
+			Action:        item.Action,
+			Symbol:        item.Symbol,
+			Side:          item.Side,
+			OrderID:       orderRequest.OrderID,
+			ClientOrderID: item.ClientOrderID,
+		}
+		if !ok {
+			results[i].Code = result.Code
+			results[i].Error = result.Message
+			results[i].ReasonCode = result.ReasonCode
+			continue
+		}
+		requests[i] = orderRequest
+	}
+
+	// 요청 순서대로 결과 수집 (전체 대기 시간 5초)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i, orderRequest := range requests {
+		if orderRequest == nil {
+			continue
+		}
+		result, ok := channels.WaitResult(ctx, orderRequest.ResultChan)
+		if !ok {
+			results[i].Code = fiber.StatusServiceUnavailable
+			results[i].Error = "Order processing is busy, check /ws/notify for the result"
+			continue
+		}
+		results[i].Success = result.Success
+		results[i].Code = result.Code
+		if !result.Success {
+			results[i].Error = result.Message
+			results[i].ReasonCode = result.ReasonCode
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"results": results,
+	})
+}
+
+// clientOrderRejected client_order_id 처리 실패 응답 (이미 사용한 ID인 경우 기존 주문 포함)
+func clientOrderRejected(c *fiber.Ctx, code int, reasonCode, message string, existing *channels.ClientOrder) error {
+	response := fiber.Map{
+		"error":       message,
+		"code":        code,
+		"reason_code": reasonCode,
+	}
+	if existing != nil {
+		response["symbol"] = existing.Symbol
+		response["orderID"] = existing.OrderID
+	}
+	return c.Status(code).JSON(response)
+}
+
+// orderPending 매칭 엔진에 전달했지만 처리 결과를 기다리지 못한 주문 응답
+// 이미 접수된 요청이므로 5xx 대신 202 로 응답해서 같은 Idempotency-Key 로 다시 보내도 중복 접수되지 않도록 함
+func orderPending(c *fiber.Ctx, orderRequest t.OrderRequest) error {
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message":       "Order processing is delayed, check /ws/notify for the result",
+		"code":          fiber.StatusAccepted,
+		"orderID":       orderRequest.OrderID,
+		"clientOrderID": orderRequest.ClientOrderID,
+	})
+}
+
+// orderRejected 매칭 엔진에서 거절된 주문 응답 (거절 사유 코드 포함)
+func orderRejected(c *fiber.Ctx, result t.Result, message string) error {
+	return c.Status(result.Code).JSON(fiber.Map{
+		"error":       message + ": " + result.Message,
+		"code":        result.Code,
+		"reason_code": result.ReasonCode,
 	})
 }
@@ -3,6 +3,7 @@ package market
 import (
 	"PJS_Exchange/app/postgresApp"
 	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/exchanges"
 	"PJS_Exchange/middlewares/auth"
 	s "PJS_Exchange/middlewares/symbol"
 	"PJS_Exchange/routes/ws"
@@ -96,10 +97,10 @@ func (sr *SymbolsRouter) symbolNow(c *fiber.Ctx) error {
 	symbolParam := c.Params("symbol")
 
 	// 현재가를 가져와야함 현재가는 가장 최근에 체결된 가격 -> 전일 종가 -> 공모가 순으로 가져옴
-	var currentPrice float64
-	if ws.TempLedger[symbolParam] != nil && ws.TempLedger[symbolParam].Size() != 0 {
+	var currentPrice template.Price
+	if ledger := ws.GetTempLedger(symbolParam); ledger != nil && ledger.Size() != 0 {
 		// 가장 최근 체결 가격(상장 직후라면 상장가)
-		currentPrice = ws.TempLedger[symbolParam].GetMostRecent().Price
+		currentPrice = ledger.GetMostRecent().Price
 	} else {
 		// TODO 전일 종가로 설정
 
@@ -109,7 +110,7 @@ func (sr *SymbolsRouter) symbolNow(c *fiber.Ctx) error {
 			//log.Printf("Error fetching IPO price for %s: %v", symbolParam, err)
 			return template.ErrorHandler(c, fiber.StatusInternalServerError, "Error fetching current price")
 		} else {
-			currentPrice = ipoPrice
+			currentPrice = exchanges.ToPrice(ipoPrice)
 		}
 	}
 
@@ -0,0 +1,277 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	BeginString = "FIX.4.4"
+
+	soh            = '\x01'
+	maxBodyLength  = 64 * 1024
+	utcTimeLayout  = "20060102-15:04:05.000"
+	utcTimeLayoutS = "20060102-15:04:05"
+)
+
+// 태그 번호 (FIX 4.4)
+const (
+	TagAvgPx             = 6
+	TagBeginSeqNo        = 7
+	TagBeginString       = 8
+	TagBodyLength        = 9
+	TagCheckSum          = 10
+	TagClOrdID           = 11
+	TagCumQty            = 14
+	TagEndSeqNo          = 16
+	TagExecID            = 17
+	TagExecInst          = 18
+	TagLastPx            = 31
+	TagLastQty           = 32
+	TagMsgSeqNum         = 34
+	TagMsgType           = 35
+	TagNewSeqNo          = 36
+	TagOrderID           = 37
+	TagOrderQty          = 38
+	TagOrdStatus         = 39
+	TagOrdType           = 40
+	TagOrigClOrdID       = 41
+	TagPossDupFlag       = 43
+	TagPrice             = 44
+	TagRefSeqNum         = 45
+	TagSenderCompID      = 49
+	TagSendingTime       = 52
+	TagSide              = 54
+	TagSymbol            = 55
+	TagTargetCompID      = 56
+	TagText              = 58
+	TagTimeInForce       = 59
+	TagTransactTime      = 60
+	TagEncryptMethod     = 98
+	TagStopPx            = 99
+	TagCxlRejReason      = 102
+	TagOrdRejReason      = 103
+	TagHeartBtInt        = 108
+	TagMaxFloor          = 111
+	TagTestReqID         = 112
+	TagOrigSendingTime   = 122
+	TagGapFillFlag       = 123
+	TagExpireTime        = 126
+	TagResetSeqNumFlag   = 141
+	TagExecType          = 150
+	TagLeavesQty         = 151
+	TagRefTagID          = 371
+	TagRefMsgType        = 372
+	TagSessionRejectRsn  = 373
+	TagCxlRejResponseTo  = 434
+	TagUsername          = 553
+	TagPassword          = 554
+	TagBusinessRejectRsn = 380
+)
+
+// 메시지 종류 (MsgType)
+const (
+	MsgTypeHeartbeat          = "0"
+	MsgTypeTestRequest        = "1"
+	MsgTypeResendRequest      = "2"
+	MsgTypeReject             = "3"
+	MsgTypeSequenceReset      = "4"
+	MsgTypeLogout             = "5"
+	MsgTypeExecutionReport    = "8"
+	MsgTypeOrderCancelReject  = "9"
+	MsgTypeLogon              = "A"
+	MsgTypeNewOrderSingle     = "D"
+	MsgTypeOrderCancelRequest = "F"
+	MsgTypeOrderCancelReplace = "G"
+	MsgTypeBusinessReject     = "j"
+)
+
+// isAdminMessage 세션 관리 메시지 여부 (재전송 요청 시 다시 보내지 않고 GapFill 로 건너뜀)
+func isAdminMessage(msgType string) bool {
+	switch msgType {
+	case MsgTypeHeartbeat, MsgTypeTestRequest, MsgTypeResendRequest, MsgTypeReject, MsgTypeSequenceReset, MsgTypeLogout, MsgTypeLogon:
+		return true
+	}
+	return false
+}
+
+type field struct {
+	tag   int
+	value string
+}
+
+// Message tag=value 필드 목록 (받은 순서 유지)
+type Message struct {
+	fields []field
+}
+
+func NewMessage(msgType string) *Message {
+	m := &Message{}
+	m.Set(TagMsgType, msgType)
+	return m
+}
+
+func (m *Message) MsgType() string {
+	return m.Get(TagMsgType)
+}
+
+// Get 태그 값 반환 (없으면 "")
+func (m *Message) Get(tag int) string {
+	for _, f := range m.fields {
+		if f.tag == tag {
+			return f.value
+		}
+	}
+	return ""
+}
+
+func (m *Message) Has(tag int) bool {
+	for _, f := range m.fields {
+		if f.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInt 정수 태그 값 반환 (없거나 정수가 아니면 false)
+func (m *Message) GetInt(tag int) (int, bool) {
+	value, err := strconv.Atoi(m.Get(tag))
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// Set 태그 값 설정 (이미 있으면 덮어씀, 구분자와 줄바꿈은 공백으로 바꿈)
+func (m *Message) Set(tag int, value string) *Message {
+	value = strings.Map(func(r rune) rune {
+		if r == soh || r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, value)
+	for i, f := range m.fields {
+		if f.tag == tag {
+			m.fields[i].value = value
+			return m
+		}
+	}
+	m.fields = append(m.fields, field{tag: tag, value: value})
+	return m
+}
+
+func (m *Message) SetInt(tag int, value int) *Message {
+	return m.Set(tag, strconv.Itoa(value))
+}
+
+// encode 헤더(header) 다음에 나머지 필드를 붙여 BodyLength, CheckSum 을 계산한 메시지 생성 (헤더에 있는 태그는 메시지 값 무시)
+func (m *Message) encode(header []field) []byte {
+	skip := map[int]bool{TagBeginString: true, TagBodyLength: true, TagCheckSum: true, TagMsgType: true}
+	for _, f := range header {
+		skip[f.tag] = true
+	}
+
+	var body bytes.Buffer
+	writeField := func(f field) {
+		body.WriteString(strconv.Itoa(f.tag))
+		body.WriteByte('=')
+		body.WriteString(f.value)
+		body.WriteByte(soh)
+	}
+	writeField(field{tag: TagMsgType, value: m.MsgType()})
+	for _, f := range header {
+		writeField(f)
+	}
+	for _, f := range m.fields {
+		if !skip[f.tag] {
+			writeField(f)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("8=" + BeginString + string(soh))
+	out.WriteString("9=" + strconv.Itoa(body.Len()) + string(soh))
+	out.Write(body.Bytes())
+	out.WriteString(fmt.Sprintf("10=%03d", checksum(out.Bytes())))
+	out.WriteByte(soh)
+	return out.Bytes()
+}
+
+func checksum(data []byte) int {
+	sum := 0
+	for _, b := range data {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+// ReadMessage BeginString, BodyLength, CheckSum 을 확인하며 메시지 하나를 읽음
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	begin, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+	if string(begin) != "8="+BeginString+string(soh) {
+		return nil, errors.New("invalid BeginString")
+	}
+
+	length, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(length, []byte("9=")) {
+		return nil, errors.New("missing BodyLength")
+	}
+	bodyLength, err := strconv.Atoi(string(length[2 : len(length)-1]))
+	if err != nil || bodyLength <= 0 || bodyLength > maxBodyLength {
+		return nil, errors.New("invalid BodyLength")
+	}
+
+	raw := make([]byte, 0, len(begin)+len(length)+bodyLength+7)
+	raw = append(raw, begin...)
+	raw = append(raw, length...)
+	body := make([]byte, bodyLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	raw = append(raw, body...)
+
+	trailer, err := r.ReadBytes(soh)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(trailer, []byte("10=")) || len(trailer) != 7 {
+		return nil, errors.New("missing CheckSum")
+	}
+	sum, err := strconv.Atoi(string(trailer[3:6]))
+	if err != nil || sum != checksum(raw) {
+		return nil, errors.New("invalid CheckSum")
+	}
+	return append(raw, trailer...), nil
+}
+
+// ParseMessage tag=value 목록으로 변환
+func ParseMessage(raw []byte) (*Message, error) {
+	m := &Message{}
+	for _, part := range bytes.Split(bytes.TrimSuffix(raw, []byte{soh}), []byte{soh}) {
+		i := bytes.IndexByte(part, '=')
+		if i <= 0 {
+			return nil, errors.New("invalid field")
+		}
+		tag, err := strconv.Atoi(string(part[:i]))
+		if err != nil || tag <= 0 {
+			return nil, errors.New("invalid tag")
+		}
+		m.fields = append(m.fields, field{tag: tag, value: string(part[i+1:])})
+	}
+	if m.MsgType() == "" {
+		return nil, errors.New("missing MsgType")
+	}
+	return m, nil
+}
@@ -0,0 +1,102 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	header := []field{
+		{TagSenderCompID, "PJSE"},
+		{TagTargetCompID, "CLIENT"},
+		{TagMsgSeqNum, "7"},
+		{TagSendingTime, "20261017-09:00:00.000"},
+	}
+
+	tests := []struct {
+		name    string
+		message *Message
+		want    []field
+	}{
+		{
+			name:    "heartbeat",
+			message: NewMessage(MsgTypeHeartbeat),
+			want:    []field{{TagMsgType, MsgTypeHeartbeat}},
+		},
+		{
+			name: "new order single",
+			message: NewMessage(MsgTypeNewOrderSingle).
+				Set(TagClOrdID, "order-1").
+				Set(TagSymbol, "PJS").
+				Set(TagSide, "1").
+				SetInt(TagOrderQty, 100).
+				Set(TagPrice, "12.34"),
+			want: []field{{TagMsgType, MsgTypeNewOrderSingle}, {TagClOrdID, "order-1"}, {TagSymbol, "PJS"}, {TagSide, "1"}, {TagOrderQty, "100"}, {TagPrice, "12.34"}},
+		},
+		{
+			name:    "separator in value",
+			message: NewMessage(MsgTypeReject).Set(TagText, "bad\x01value\n"),
+			want:    []field{{TagMsgType, MsgTypeReject}, {TagText, "bad value "}},
+		},
+		{
+			name:    "header tags in message ignored",
+			message: NewMessage(MsgTypeTestRequest).Set(TagMsgSeqNum, "99").Set(TagTestReqID, "ping"),
+			want:    []field{{TagMsgType, MsgTypeTestRequest}, {TagTestReqID, "ping"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := tt.message.encode(header)
+			read, err := ReadMessage(bufio.NewReader(bytes.NewReader(raw)))
+			if err != nil {
+				t.Fatalf("ReadMessage() error = %v", err)
+			}
+			if !bytes.Equal(read, raw) {
+				t.Fatalf("ReadMessage() = %q, want %q", read, raw)
+			}
+			m, err := ParseMessage(read)
+			if err != nil {
+				t.Fatalf("ParseMessage() error = %v", err)
+			}
+
+			want := []field{{TagBeginString, BeginString}, {TagBodyLength, m.Get(TagBodyLength)}, tt.want[0]}
+			want = append(want, header...)
+			want = append(want, tt.want[1:]...)
+			want = append(want, field{TagCheckSum, m.Get(TagCheckSum)})
+			if !reflect.DeepEqual(m.fields, want) {
+				t.Errorf("ParseMessage() fields = %v, want %v", m.fields, want)
+			}
+		})
+	}
+}
+
+func TestReadMessageRejectsCorruption(t *testing.T) {
+	valid := string(NewMessage(MsgTypeHeartbeat).encode([]field{{TagMsgSeqNum, "1"}}))
+	body := valid[:strings.LastIndex(valid, "10=")]
+
+	tests := []struct {
+		name string
+		raw  string
+		err  string
+	}{
+		{"checksum mismatch", body + "10=000\x01", "invalid CheckSum"},
+		{"missing checksum", body + "11=000\x01", "missing CheckSum"},
+		{"short checksum", body + "10=1\x01", "missing CheckSum"},
+		{"begin string", strings.Replace(valid, "FIX.4.4", "FIX.4.2", 1), "invalid BeginString"},
+		{"body length", strings.Replace(valid, "9=", "9=x", 1), "invalid BodyLength"},
+		{"missing body length", strings.Replace(valid, "9=", "99=", 1), "missing BodyLength"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadMessage(bufio.NewReader(strings.NewReader(tt.raw)))
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("ReadMessage() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
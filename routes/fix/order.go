@@ -0,0 +1,444 @@
+package fix
+
+import (
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/exchanges/channels"
+	t "PJS_Exchange/template"
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	fixSides = map[string]string{"1": t.SideBuy, "2": t.SideSell}
+
+	fixOrderTypes = map[string]string{
+		"1": t.OrderTypeMarket,
+		"2": t.OrderTypeLimit,
+		"3": t.OrderTypeStop,
+		"4": t.OrderTypeStopLimit,
+	}
+
+	fixTimeInForces = map[string]string{
+		"0": t.TimeInForceDay,
+		"1": t.TimeInForceGTC,
+		"3": t.TimeInForceIOC,
+		"4": t.TimeInForceFOK,
+		"6": t.TimeInForceGTD,
+	}
+
+	fixExecTypes = map[string]string{
+		t.ExecTypeNew:       "0",
+		t.ExecTypePartial:   "F",
+		t.ExecTypeFill:      "F",
+		t.ExecTypeCancel:    "4",
+		t.ExecTypeReplace:   "5",
+		t.ExecTypeReject:    "8",
+		t.ExecTypeRestated:  "D",
+		t.ExecTypeTriggered: "L",
+	}
+
+	fixOrdStatuses = map[string]string{
+		t.StatusOpen:            "0",
+		t.StatusModified:        "0",
+		t.StatusPartiallyFilled: "1",
+		t.StatusFilled:          "2",
+		t.StatusCanceled:        "4",
+		t.StatusRejected:        "8",
+	}
+)
+
+// reverseLookup 거래소 값에 해당하는 FIX 값 (없으면 "")
+func reverseLookup(values map[string]string, value string) string {
+	for fixValue, v := range values {
+		if v == value {
+			return fixValue
+		}
+	}
+	return ""
+}
+
+func formatPrice(price float64) string {
+	precision := exchanges.PricePrecision()
+	return strconv.FormatFloat(price/math.Pow10(precision), 'f', precision, 64)
+}
+
+// parsePrice 통화 단위 가격을 고정 소수점 가격으로 변환 (최소 단위로 나누어 떨어지지 않으면 false)
+func parsePrice(value string) (t.Price, bool) {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 || !exchanges.IsPriceRepresentable(price) {
+		return 0, false
+	}
+	return exchanges.ToPrice(price), true
+}
+
+// parseQuantity 정수 수량만 허용 (예: "100", "100.0")
+func parseQuantity(value string) (int, bool) {
+	quantity, err := strconv.ParseFloat(value, 64)
+	if err != nil || quantity <= 0 || quantity != math.Trunc(quantity) || quantity > math.MaxInt32 {
+		return 0, false
+	}
+	return int(quantity), true
+}
+
+func parseUTCTimestamp(value string) (time.Time, bool) {
+	for _, layout := range []string{utcTimeLayout, utcTimeLayoutS} {
+		if ts, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return ts, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// toOrderItem NewOrderSingle, OrderCancelRequest, OrderCancelReplaceRequest 를 일괄 주문 요청 형식으로 변환
+// 변환할 수 없으면 잘못된 태그와 메시지 반환
+func (s *Session) toOrderItem(m *Message) (t.BatchOrderItem, int, string) {
+	item := t.BatchOrderItem{Symbol: m.Get(TagSymbol)}
+
+	switch m.MsgType() {
+	case MsgTypeNewOrderSingle:
+		item.Action = t.BatchActionNew
+		item.ClientOrderID = m.Get(TagClOrdID)
+	case MsgTypeOrderCancelRequest:
+		item.Action = t.BatchActionCancel
+	case MsgTypeOrderCancelReplace:
+		item.Action = t.BatchActionModify
+	}
+	if m.Get(TagClOrdID) == "" {
+		return item, TagClOrdID, "ClOrdID is required"
+	}
+	if item.Symbol == "" {
+		return item, TagSymbol, "Symbol is required"
+	}
+	side, ok := fixSides[m.Get(TagSide)]
+	if !ok {
+		return item, TagSide, "Unsupported Side"
+	}
+	item.Side = side
+
+	// 취소 / 정정 대상 주문은 OrderID 우선, 없으면 OrigClOrdID (이 세션에서 정정 / 취소할 때 사용한 ClOrdID 포함)
+	if item.Action != t.BatchActionNew {
+		if orderID := m.Get(TagOrderID); orderID != "" && orderID != "NONE" {
+			item.OrderID = orderID
+		} else if origClOrdID := m.Get(TagOrigClOrdID); origClOrdID != "" {
+			s.lock.Lock()
+			orderID, ok := s.aliases[origClOrdID]
+			s.lock.Unlock()
+			if ok {
+				item.OrderID = orderID
+			} else if order, ok := channels.LookupClientOrder(s.userID, origClOrdID); ok && order.Symbol == item.Symbol {
+				item.OrderID = order.OrderID
+			} else {
+				item.ClientOrderID = origClOrdID // 매칭 엔진에서 Unknown ClientOrderID 로 거절
+			}
+		} else {
+			return item, TagOrigClOrdID, "OrderID or OrigClOrdID is required"
+		}
+		if item.Action == t.BatchActionCancel {
+			return item, 0, ""
+		}
+	}
+
+	orderType, ok := fixOrderTypes[m.Get(TagOrdType)]
+	if !ok {
+		return item, TagOrdType, "Unsupported OrdType"
+	}
+	item.OrderType = orderType
+
+	if item.Quantity, ok = parseQuantity(m.Get(TagOrderQty)); !ok {
+		return item, TagOrderQty, "Invalid OrderQty"
+	}
+	if m.Has(TagPrice) {
+		if item.Price, ok = parsePrice(m.Get(TagPrice)); !ok {
+			return item, TagPrice, "Invalid Price"
+		}
+	}
+	if m.Has(TagStopPx) {
+		if item.StopPrice, ok = parsePrice(m.Get(TagStopPx)); !ok {
+			return item, TagStopPx, "Invalid StopPx"
+		}
+	}
+	if item.Action == t.BatchActionModify {
+		return item, 0, ""
+	}
+
+	// 시장가 주문의 DAY(기본값)는 거래소 기본값(IOC)으로 처리
+	if tif := m.Get(TagTimeInForce); tif != "" && !(item.OrderType == t.OrderTypeMarket && tif == "0") {
+		if item.TimeInForce, ok = fixTimeInForces[tif]; !ok {
+			return item, TagTimeInForce, "Unsupported TimeInForce"
+		}
+	}
+	if item.TimeInForce == t.TimeInForceGTD {
+		expireAt, ok := parseUTCTimestamp(m.Get(TagExpireTime))
+		if !ok {
+			return item, TagExpireTime, "Invalid ExpireTime"
+		}
+		item.ExpireAt = expireAt.UnixMilli()
+	}
+	// ExecInst 6 (Participate don't initiate) 는 Post-Only 거절
+	if strings.Contains(" "+m.Get(TagExecInst)+" ", " 6 ") {
+		item.PostOnly = t.PostOnlyReject
+	}
+	if m.Has(TagMaxFloor) {
+		if item.DisplayQuantity, ok = parseQuantity(m.Get(TagMaxFloor)); !ok {
+			return item, TagMaxFloor, "Invalid MaxFloor"
+		}
+	}
+	return item, 0, ""
+}
+
+// handleOrder 주문 메시지를 매칭 엔진에 전달 (접수되면 실행 보고서로, 거절되면 ExecutionReport(Rejected) 또는 OrderCancelReject 로 응답)
+func (c *connection) handleOrder(m *Message) {
+	s := c.session
+
+	item, tag, message := s.toOrderItem(m)
+	if tag != 0 {
+		s.sendReject(m, 5, tag, message)
+		return
+	}
+
+	s.lock.Lock()
+	user, apiKey := s.user, s.apiKey
+	s.lock.Unlock()
+
+	// 매칭 엔진의 실행 보고서에 요청의 ClOrdID 를 넣을 수 있도록 전달 전에 등록
+	if item.OrderID != "" {
+		s.lock.Lock()
+		s.aliases[m.Get(TagClOrdID)] = item.OrderID
+		s.pending[item.OrderID] = pendingRequest{clOrdID: m.Get(TagClOrdID), origClOrdID: m.Get(TagOrigClOrdID)}
+		s.lock.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	orderReq, result, ok := channels.OP.SubmitItem(ctx, item, user, apiKey, nil)
+	cancel()
+	if !ok {
+		s.forgetRequest(m, item)
+		s.rejectOrder(m, item, orderReq.OrderID, result)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		result, ok := channels.WaitResult(ctx, orderReq.ResultChan)
+		if !ok || result.Success {
+			return
+		}
+		s.forgetRequest(m, item)
+		s.rejectOrder(m, item, orderReq.OrderID, result)
+	}()
+}
+
+// forgetRequest 거절된 취소 / 정정 요청의 ClOrdID 등록 해제
+func (s *Session) forgetRequest(m *Message, item t.BatchOrderItem) {
+	if item.OrderID == "" {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.aliases, m.Get(TagClOrdID))
+	if pending, ok := s.pending[item.OrderID]; ok && pending.clOrdID == m.Get(TagClOrdID) {
+		delete(s.pending, item.OrderID)
+	}
+}
+
+// ordRejReason 거절 사유 코드를 OrdRejReason (103) 으로 변환
+func ordRejReason(result t.Result) int {
+	switch {
+	case result.ReasonCode == t.ReasonDuplicateClientOrderID:
+		return 6 // Duplicate Order
+	case result.ReasonCode == t.ReasonMarketHalted || result.Code == 503:
+		return 2 // Exchange closed
+	case result.ReasonCode == t.ReasonPriceOutOfLimit:
+		return 8 // Price exceeds current price band
+	case result.ReasonCode == t.ReasonInvalidQuantity || result.ReasonCode == t.ReasonBelowMinimumQuantity:
+		return 13 // Incorrect quantity
+	case result.Code == 404:
+		return 1 // Unknown symbol
+	}
+	return 99 // Other
+}
+
+// rejectOrder 신규 주문은 ExecutionReport(Rejected), 취소 / 정정은 OrderCancelReject 로 거절
+func (s *Session) rejectOrder(m *Message, item t.BatchOrderItem, orderID string, result t.Result) {
+	if orderID == "" {
+		orderID = "NONE"
+	}
+
+	if item.Action == t.BatchActionNew {
+		report := NewMessage(MsgTypeExecutionReport).
+			Set(TagOrderID, orderID).
+			Set(TagClOrdID, m.Get(TagClOrdID)).
+			Set(TagExecID, "R-"+strconv.FormatInt(time.Now().UnixNano(), 36)).
+			Set(TagExecType, "8").
+			Set(TagOrdStatus, "8").
+			SetInt(TagOrdRejReason, ordRejReason(result)).
+			Set(TagSymbol, m.Get(TagSymbol)).
+			Set(TagSide, m.Get(TagSide)).
+			Set(TagOrdType, m.Get(TagOrdType)).
+			Set(TagOrderQty, m.Get(TagOrderQty)).
+			Set(TagLeavesQty, "0").
+			Set(TagCumQty, "0").
+			Set(TagAvgPx, "0").
+			Set(TagTransactTime, utcTimestamp(time.Now())).
+			Set(TagText, result.Message)
+		_ = s.send(report)
+		return
+	}
+
+	// 취소 / 정정 대상 주문이 남아있으면 현재 상태, 없으면 Rejected
+	status, cxlRejReason := "8", 1 // Unknown order
+	if orderID != "NONE" {
+		for _, order := range channels.OP.GetOpenOrders(item.Symbol, s.userID) {
+			if order.OrderID == orderID {
+				status, cxlRejReason = fixOrdStatuses[order.Status], 99
+				break
+			}
+		}
+	}
+	responseTo := "1"
+	if item.Action == t.BatchActionModify {
+		responseTo = "2"
+	}
+	reject := NewMessage(MsgTypeOrderCancelReject).
+		Set(TagOrderID, orderID).
+		Set(TagClOrdID, m.Get(TagClOrdID)).
+		Set(TagOrigClOrdID, m.Get(TagOrigClOrdID)).
+		Set(TagOrdStatus, status).
+		Set(TagCxlRejResponseTo, responseTo).
+		SetInt(TagCxlRejReason, cxlRejReason).
+		Set(TagText, result.Message)
+	_ = s.send(reject)
+}
+
+// dispatchReport 사용자의 FIX 세션 대기열에 실행 보고서 추가 (매칭 엔진 고루틴에서 호출되므로 전송은 세션 고루틴에서 처리)
+// 거절은 요청한 세션에만 응답하므로 제외
+func dispatchReport(report t.ExecutionReport) {
+	if report.ExecType == t.ExecTypeReject {
+		return
+	}
+
+	sessionLock.Lock()
+	targets := make([]*Session, 0, 1)
+	for _, s := range sessions {
+		if s.userID == report.UserID {
+			targets = append(targets, s)
+		}
+	}
+	sessionLock.Unlock()
+
+	for _, s := range targets {
+		s.enqueueReport(report)
+	}
+}
+
+// enqueueReport 실행 보고서를 대기열에 추가 (매칭 엔진을 막지 않도록 기다리지 않음)
+// 버리면 번호가 붙지 않아 재전송 요청으로도 받을 수 없으므로 모두 보관하고, 대기열이 너무 길어지면 연결을 끊어 저장만 하도록 함
+func (s *Session) enqueueReport(report t.ExecutionReport) {
+	s.queueLock.Lock()
+	s.queue = append(s.queue, report)
+	if len(s.queue) > reportQueueSize {
+		s.overflow.Store(true)
+	}
+	s.queueLock.Unlock()
+
+	select {
+	case s.queueSignal <- struct{}{}:
+	default:
+	}
+}
+
+// sendReports 대기열의 실행 보고서 전송 (연결이 끊긴 동안에도 번호를 붙여 보관하므로 재접속 후 재전송 요청으로 받을 수 있음)
+func (s *Session) sendReports() {
+	for range s.queueSignal {
+		for {
+			s.queueLock.Lock()
+			reports := s.queue
+			s.queue = nil
+			s.queueLock.Unlock()
+			if len(reports) == 0 {
+				break
+			}
+			for _, report := range reports {
+				s.sendReport(report)
+			}
+		}
+	}
+}
+
+func (s *Session) sendReport(report t.ExecutionReport) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.apiKey == nil || !s.apiKey.Scopes.OrderNotify {
+		return
+	}
+	// 대기열이 밀린 연결은 끊고 저장만 함 (재접속 후 재전송 요청으로 받음)
+	if s.overflow.CompareAndSwap(true, false) && s.conn != nil {
+		log.Printf("FIX session %s report queue is full, disconnecting", s.targetCompID)
+		s.closeConnLocked()
+	}
+
+	clOrdID, origClOrdID := report.ClientOrderID, ""
+	if report.ExecType == t.ExecTypeCancel || report.ExecType == t.ExecTypeReplace {
+		if pending, ok := s.pending[report.OrderID]; ok {
+			clOrdID, origClOrdID = pending.clOrdID, pending.origClOrdID
+			delete(s.pending, report.OrderID)
+		}
+	}
+	if err := s.sendLocked(executionReportMessage(report, clOrdID, origClOrdID)); err != nil && s.conn != nil {
+		// 느리거나 끊긴 연결은 닫고 이후 보고서는 저장만 함 (재접속 후 재전송 요청으로 받음)
+		log.Printf("FIX session %s write failed, disconnecting: %v", s.targetCompID, err)
+		s.closeConnLocked()
+	}
+}
+
+// executionReportMessage 실행 보고서를 ExecutionReport (35=8) 로 변환
+func executionReportMessage(report t.ExecutionReport, clOrdID, origClOrdID string) *Message {
+	if clOrdID == "" {
+		clOrdID = report.OrderID
+	}
+	execID := report.ExecutionID
+	if execID == "" {
+		execID = strconv.Itoa(report.UserID) + "-" + strconv.FormatInt(report.Seq, 10)
+	}
+
+	m := NewMessage(MsgTypeExecutionReport).
+		Set(TagOrderID, report.OrderID).
+		Set(TagClOrdID, clOrdID).
+		Set(TagExecID, execID).
+		Set(TagExecType, fixExecTypes[report.ExecType]).
+		Set(TagOrdStatus, fixOrdStatuses[report.Status]).
+		Set(TagSymbol, report.Symbol).
+		Set(TagSide, reverseLookup(fixSides, report.Side)).
+		Set(TagOrdType, reverseLookup(fixOrderTypes, report.OrderType)).
+		SetInt(TagOrderQty, report.Quantity).
+		SetInt(TagLastQty, report.LastQuantity).
+		Set(TagLastPx, formatPrice(float64(report.LastPrice))).
+		SetInt(TagCumQty, report.CumQuantity).
+		SetInt(TagLeavesQty, report.LeavesQuantity).
+		Set(TagAvgPx, formatPrice(report.AvgPrice)).
+		Set(TagTransactTime, utcTimestamp(time.UnixMilli(report.Timestamp)))
+	if origClOrdID != "" {
+		m.Set(TagOrigClOrdID, origClOrdID)
+	}
+	if report.OrderType != t.OrderTypeMarket {
+		m.Set(TagPrice, formatPrice(float64(report.Price)))
+	}
+	if report.StopPrice != 0 {
+		m.Set(TagStopPx, formatPrice(float64(report.StopPrice)))
+	}
+	if tif := reverseLookup(fixTimeInForces, report.TimeInForce); tif != "" {
+		m.Set(TagTimeInForce, tif)
+	}
+	if report.ExpireAt != 0 {
+		m.Set(TagExpireTime, utcTimestamp(time.UnixMilli(report.ExpireAt)))
+	}
+	if report.Reason != "" {
+		m.Set(TagText, report.Reason)
+	}
+	return m
+}
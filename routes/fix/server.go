@@ -0,0 +1,202 @@
+package fix
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/exchanges/channels"
+	"PJS_Exchange/utils"
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	maxHeartBtInt = 300
+)
+
+// Enabled FIX_PORT 가 설정된 경우에만 FIX 게이트웨이 사용
+func Enabled() bool {
+	return utils.GetEnv("FIX_PORT", "") != ""
+}
+
+func senderCompID() string {
+	return utils.GetEnv("FIX_SENDER_COMP_ID", "PJSE")
+}
+
+// Start FIX 4.4 주문 접수 게이트웨이 시작 (TCP 연결을 받아 세션마다 고루틴으로 처리, 리스너가 닫히면 반환)
+func Start() error {
+	listener, err := net.Listen("tcp", ":"+utils.GetEnv("FIX_PORT", ""))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	channels.AddExecutionReportListener(dispatchReport)
+	log.Printf("FIX gateway listening on %s as %s", listener.Addr(), senderCompID())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			return err
+		}
+		go handleConn(conn)
+	}
+}
+
+// handleConn 첫 메시지로 Logon 을 받아 인증한 뒤 세션 처리
+func handleConn(conn net.Conn) {
+	c := &connection{conn: conn, reader: bufio.NewReader(conn)}
+
+	if err := conn.SetReadDeadline(time.Now().Add(logonTimeout)); err != nil {
+		_ = conn.Close()
+		return
+	}
+	raw, err := ReadMessage(c.reader)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	m, err := ParseMessage(raw)
+	if err != nil || m.MsgType() != MsgTypeLogon {
+		log.Printf("FIX connection from %s closed: first message is not Logon", conn.RemoteAddr())
+		_ = conn.Close()
+		return
+	}
+
+	s, err := logon(conn, m)
+	if err != nil {
+		log.Printf("FIX logon from %s (%s) failed: %v", conn.RemoteAddr(), m.Get(TagSenderCompID), err)
+		_ = conn.Close()
+		return
+	}
+	c.session = s
+	log.Printf("FIX session %s logged on (user %s)", s.targetCompID, s.user.Username)
+	defer log.Printf("FIX session %s disconnected", s.targetCompID)
+
+	c.run()
+}
+
+// logon API 키(Password) 인증, 메시지 번호 확인 후 세션 연결 및 Logon 응답
+func logon(conn net.Conn, m *Message) (*Session, error) {
+	targetCompID := m.Get(TagSenderCompID)
+	if targetCompID == "" || m.Get(TagTargetCompID) != senderCompID() {
+		return nil, errors.New("invalid CompID")
+	}
+	if method := m.Get(TagEncryptMethod); method != "" && method != "0" {
+		return nil, errors.New("unsupported EncryptMethod")
+	}
+	heartBtInt, ok := m.GetInt(TagHeartBtInt)
+	if !ok || heartBtInt <= 0 || heartBtInt > maxHeartBtInt {
+		return nil, errors.New("invalid HeartBtInt")
+	}
+	seq, ok := m.GetInt(TagMsgSeqNum)
+	if !ok {
+		return nil, errors.New("missing MsgSeqNum")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	apiKey, err := postgresApp.Get().APIKeyRepo().AuthenticateAPIKey(ctx, m.Get(TagPassword))
+	if err != nil || apiKey == nil || apiKey.Status != "active" {
+		return nil, errors.New("API key authentication failed")
+	}
+	userID, err := strconv.Atoi(apiKey.UserID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	user, err := postgresApp.Get().UserRepo().GetUserByID(ctx, userID)
+	if err != nil || user == nil || !user.Enabled {
+		return nil, errors.New("user account is not enabled")
+	}
+
+	sessionLock.Lock()
+	s, ok := sessions[targetCompID]
+	if !ok {
+		// 저장소에 기록된 사용자와 다르면 열지 않음 (재시작 후 다른 사용자가 먼저 로그온해도 메시지를 받을 수 없도록)
+		store, err := OpenStore(senderCompID(), targetCompID, user.ID)
+		if err != nil {
+			sessionLock.Unlock()
+			return nil, err
+		}
+		s = &Session{
+			senderCompID: senderCompID(),
+			targetCompID: targetCompID,
+			userID:       user.ID,
+			store:        store,
+			aliases:      make(map[string]string),
+			pending:      make(map[string]pendingRequest),
+			queueSignal:  make(chan struct{}, 1),
+		}
+		sessions[targetCompID] = s
+		go s.sendReports()
+	}
+	sessionLock.Unlock()
+
+	if s.userID != user.ID {
+		return nil, errors.New("CompID is used by another user")
+	}
+
+	s.lock.Lock()
+	if s.conn != nil {
+		s.lock.Unlock()
+		return nil, errors.New("session is already logged on")
+	}
+	s.conn = conn
+	s.user, s.apiKey = user, apiKey
+	s.heartBtInt = time.Duration(heartBtInt) * time.Second
+	s.resendUntil = 0
+
+	// 다음 날이 되었거나 상대방이 요청하면 번호 초기화
+	reset := m.Get(TagResetSeqNumFlag) == "Y"
+	if reset || s.store.Expired() {
+		if err := s.store.Reset(); err != nil {
+			s.conn = nil
+			s.lock.Unlock()
+			return nil, err
+		}
+		s.aliases = make(map[string]string)
+		s.pending = make(map[string]pendingRequest)
+	}
+
+	now := time.Now().UnixMilli()
+	s.lastReceived.Store(now)
+	s.lastSent.Store(now)
+
+	expected := s.store.NextTargetSeq()
+	if seq < expected {
+		logout := NewMessage(MsgTypeLogout).Set(TagText, "MsgSeqNum too low, expecting "+strconv.Itoa(expected)+" but received "+strconv.Itoa(seq))
+		_ = s.sendLocked(logout)
+		s.conn = nil
+		s.lock.Unlock()
+		return nil, errors.New("MsgSeqNum too low")
+	}
+
+	response := NewMessage(MsgTypeLogon).
+		Set(TagEncryptMethod, "0").
+		SetInt(TagHeartBtInt, heartBtInt)
+	if reset {
+		response.Set(TagResetSeqNumFlag, "Y")
+	}
+	err = s.sendLocked(response)
+	s.lock.Unlock()
+	if err != nil {
+		s.detach(conn)
+		return nil, err
+	}
+
+	if seq > expected {
+		s.requestResend(expected, seq)
+	} else if err := s.store.SetNextTargetSeq(seq + 1); err != nil {
+		log.Printf("Failed to store FIX sequence of %s: %v", targetCompID, err)
+	}
+	return s, nil
+}
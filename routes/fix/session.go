@@ -0,0 +1,382 @@
+package fix
+
+import (
+	"PJS_Exchange/databases/postgresql"
+	t "PJS_Exchange/template"
+	"bufio"
+	"errors"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	logonTimeout    = 10 * time.Second
+	writeWait       = 10 * time.Second
+	reportQueueSize = 4096
+)
+
+var (
+	sessions    = make(map[string]*Session) // 상대방 CompID 별 세션 (연결이 끊겨도 유지해서 그 사이 실행 보고서를 보관)
+	sessionLock sync.Mutex
+
+	errLoggedOut = errors.New("logged out")
+)
+
+// pendingRequest 응답을 기다리는 취소 / 정정 요청 (실행 보고서에 요청의 ClOrdID 를 넣기 위해 보관)
+type pendingRequest struct {
+	clOrdID     string
+	origClOrdID string
+}
+
+// Session 상대방 CompID 별 FIX 세션 (메시지 번호, 보낸 메시지 저장소)
+type Session struct {
+	senderCompID string // 거래소 CompID
+	targetCompID string // 상대방 CompID
+	userID       int
+	store        *Store
+
+	lock        sync.Mutex // 보내기, 저장소, 아래 필드
+	conn        net.Conn   // 연결이 끊겨 있으면 nil
+	user        *postgresql.User
+	apiKey      *postgresql.APIKey
+	heartBtInt  time.Duration
+	resendUntil int                       // 재전송 요청한 마지막 번호 (그 번호까지 받기 전에는 다시 요청하지 않음)
+	aliases     map[string]string         // 정정 / 취소 요청의 ClOrdID -> 주문 ID (이후 요청에서 OrigClOrdID 로 사용 가능)
+	pending     map[string]pendingRequest // 주문 ID 별 응답을 기다리는 취소 / 정정 요청
+
+	queueLock   sync.Mutex
+	queue       []t.ExecutionReport // 보낼 실행 보고서 대기열 (크기 제한 없음, reportQueueSize 를 넘으면 overflow 설정)
+	queueSignal chan struct{}
+	overflow    atomic.Bool // 대기열이 밀려 연결을 끊어야 하는지 여부
+
+	lastSent     atomic.Int64
+	lastReceived atomic.Int64
+}
+
+// connection 연결 하나의 읽기 상태
+type connection struct {
+	session *Session
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+func utcTimestamp(t time.Time) string {
+	return t.UTC().Format(utcTimeLayout)
+}
+
+// send 다음 번호로 메시지를 보냄 (연결이 끊겨 있어도 번호를 붙여 보관하므로 재접속 후 재전송 요청으로 받을 수 있음)
+func (s *Session) send(m *Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.sendLocked(m)
+}
+
+func (s *Session) sendLocked(m *Message) error {
+	seq := s.store.NextSenderSeq()
+	raw := m.encode([]field{
+		{TagSenderCompID, s.senderCompID},
+		{TagTargetCompID, s.targetCompID},
+		{TagMsgSeqNum, strconv.Itoa(seq)},
+		{TagSendingTime, utcTimestamp(time.Now())},
+	})
+
+	// 먼저 저장해야 전송에 실패해도 재전송 가능
+	stored := raw
+	if isAdminMessage(m.MsgType()) {
+		stored = nil
+	}
+	if err := s.store.SaveMessage(seq, stored); err != nil {
+		log.Printf("Failed to store FIX message %d of %s: %v", seq, s.targetCompID, err)
+	}
+	return s.writeLocked(raw)
+}
+
+func (s *Session) writeLocked(raw []byte) error {
+	if s.conn == nil {
+		return nil
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(raw); err != nil {
+		return err
+	}
+	s.lastSent.Store(time.Now().UnixMilli())
+	return nil
+}
+
+// resend begin 부터 end 까지 보낸 메시지 재전송 (세션 관리 메시지는 SequenceReset-GapFill 로 건너뜀, end 가 0이면 마지막까지)
+func (s *Session) resend(begin, end int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	last := s.store.NextSenderSeq() - 1
+	if end == 0 || end > last {
+		end = last
+	}
+	if begin < 1 {
+		begin = 1
+	}
+
+	gapFrom := 0
+	flushGap := func(next int) error {
+		if gapFrom == 0 {
+			return nil
+		}
+		gap := NewMessage(MsgTypeSequenceReset).
+			Set(TagGapFillFlag, "Y").
+			SetInt(TagNewSeqNo, next)
+		raw := gap.encode([]field{
+			{TagSenderCompID, s.senderCompID},
+			{TagTargetCompID, s.targetCompID},
+			{TagMsgSeqNum, strconv.Itoa(gapFrom)},
+			{TagPossDupFlag, "Y"},
+			{TagSendingTime, utcTimestamp(time.Now())},
+		})
+		gapFrom = 0
+		return s.writeLocked(raw)
+	}
+
+	for seq := begin; seq <= end; seq++ {
+		stored, ok := s.store.Message(seq)
+		var original *Message
+		if ok {
+			original, _ = ParseMessage(stored)
+		}
+		if original == nil {
+			if gapFrom == 0 {
+				gapFrom = seq
+			}
+			continue
+		}
+		if err := flushGap(seq); err != nil {
+			return err
+		}
+
+		raw := original.encode([]field{
+			{TagSenderCompID, s.senderCompID},
+			{TagTargetCompID, s.targetCompID},
+			{TagMsgSeqNum, strconv.Itoa(seq)},
+			{TagPossDupFlag, "Y"},
+			{TagSendingTime, utcTimestamp(time.Now())},
+			{TagOrigSendingTime, original.Get(TagSendingTime)},
+		})
+		if err := s.writeLocked(raw); err != nil {
+			return err
+		}
+	}
+	return flushGap(end + 1)
+}
+
+// sendReject 세션 수준 거절 (Reject)
+func (s *Session) sendReject(ref *Message, reason int, refTag int, text string) {
+	reject := NewMessage(MsgTypeReject).
+		Set(TagRefSeqNum, ref.Get(TagMsgSeqNum)).
+		Set(TagRefMsgType, ref.MsgType()).
+		SetInt(TagSessionRejectRsn, reason).
+		Set(TagText, text)
+	if refTag != 0 {
+		reject.SetInt(TagRefTagID, refTag)
+	}
+	_ = s.send(reject)
+}
+
+// sendLogout 로그아웃 메시지 전송 (연결은 호출한 쪽에서 닫음)
+func (s *Session) sendLogout(text string) {
+	logout := NewMessage(MsgTypeLogout)
+	if text != "" {
+		logout.Set(TagText, text)
+	}
+	_ = s.send(logout)
+}
+
+// requestResend 받지 못한 번호부터 재전송 요청 (이미 요청한 범위면 생략)
+func (s *Session) requestResend(expected, received int) {
+	s.lock.Lock()
+	if s.resendUntil >= received {
+		s.lock.Unlock()
+		return
+	}
+	s.resendUntil = received
+	s.lock.Unlock()
+
+	_ = s.send(NewMessage(MsgTypeResendRequest).
+		SetInt(TagBeginSeqNo, expected).
+		SetInt(TagEndSeqNo, 0))
+}
+
+// detach 연결 종료 (세션과 저장소는 재접속에 대비해 유지)
+// closeConnLocked 연결을 닫고 이후 메시지는 저장만 함 (s.lock 을 잡은 상태에서 호출할 것)
+func (s *Session) closeConnLocked() {
+	_ = s.conn.Close()
+	s.conn = nil
+	s.resendUntil = 0
+}
+
+func (s *Session) detach(conn net.Conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.conn == conn {
+		s.conn = nil
+		s.resendUntil = 0
+	}
+	_ = conn.Close()
+}
+
+// run 로그온 후 메시지 처리 (연결이 끊기거나 로그아웃하면 반환)
+func (c *connection) run() {
+	s := c.session
+	defer s.detach(c.conn)
+
+	done := make(chan struct{})
+	defer close(done)
+	go c.heartbeat(done)
+
+	for {
+		raw, err := ReadMessage(c.reader)
+		if err != nil {
+			return
+		}
+		s.lastReceived.Store(time.Now().UnixMilli())
+
+		m, err := ParseMessage(raw)
+		if err != nil {
+			continue
+		}
+		if err := c.handleMessage(m); err != nil {
+			if !errors.Is(err, errLoggedOut) {
+				log.Printf("FIX session %s closed: %v", s.targetCompID, err)
+			}
+			return
+		}
+	}
+}
+
+// heartbeat 보낸 메시지가 없으면 Heartbeat, 받은 메시지가 없으면 TestRequest 를 보내고 응답이 없으면 연결 종료
+func (c *connection) heartbeat(done chan struct{}) {
+	s := c.session
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	testRequested := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		interval := s.heartBtInt
+		if now.Sub(time.UnixMilli(s.lastSent.Load())) >= interval {
+			_ = s.send(NewMessage(MsgTypeHeartbeat))
+		}
+
+		idle := now.Sub(time.UnixMilli(s.lastReceived.Load()))
+		switch {
+		case idle < interval+interval/5:
+			testRequested = false
+		case idle >= 2*interval+interval/5:
+			log.Printf("FIX session %s timed out", s.targetCompID)
+			_ = c.conn.Close()
+			return
+		case !testRequested:
+			testRequested = true
+			_ = s.send(NewMessage(MsgTypeTestRequest).Set(TagTestReqID, "TEST-"+strconv.FormatInt(now.UnixMilli(), 10)))
+		}
+	}
+}
+
+// handleMessage 메시지 번호 확인 후 종류별 처리 (연결을 끊어야 하면 에러 반환)
+func (c *connection) handleMessage(m *Message) error {
+	s := c.session
+
+	if m.Get(TagSenderCompID) != s.targetCompID || m.Get(TagTargetCompID) != s.senderCompID {
+		s.sendReject(m, 9, TagSenderCompID, "CompID problem")
+		s.sendLogout("CompID problem")
+		return errors.New("invalid CompID")
+	}
+	seq, ok := m.GetInt(TagMsgSeqNum)
+	if !ok {
+		s.sendLogout("MsgSeqNum missing")
+		return errors.New("missing MsgSeqNum")
+	}
+
+	// 재전송 요청은 번호가 맞지 않아도 먼저 처리
+	if m.MsgType() == MsgTypeResendRequest {
+		begin, _ := m.GetInt(TagBeginSeqNo)
+		end, _ := m.GetInt(TagEndSeqNo)
+		if err := s.resend(begin, end); err != nil {
+			return err
+		}
+	}
+
+	// SequenceReset-Reset 은 번호와 관계없이 다음에 받을 번호 변경
+	if m.MsgType() == MsgTypeSequenceReset && m.Get(TagGapFillFlag) != "Y" {
+		return c.handleSequenceReset(m)
+	}
+
+	expected := s.store.NextTargetSeq()
+	switch {
+	case seq > expected:
+		s.requestResend(expected, seq)
+		return nil
+	case seq < expected:
+		if m.Get(TagPossDupFlag) == "Y" {
+			return nil
+		}
+		s.sendLogout("MsgSeqNum too low, expecting " + strconv.Itoa(expected) + " but received " + strconv.Itoa(seq))
+		return errors.New("MsgSeqNum too low")
+	}
+
+	if m.MsgType() == MsgTypeSequenceReset {
+		return c.handleSequenceReset(m)
+	}
+	if err := s.store.SetNextTargetSeq(seq + 1); err != nil {
+		log.Printf("Failed to store FIX sequence of %s: %v", s.targetCompID, err)
+	}
+
+	switch m.MsgType() {
+	case MsgTypeHeartbeat, MsgTypeResendRequest:
+	case MsgTypeTestRequest:
+		_ = s.send(NewMessage(MsgTypeHeartbeat).Set(TagTestReqID, m.Get(TagTestReqID)))
+	case MsgTypeReject:
+		log.Printf("FIX session %s rejected message %s: %s", s.targetCompID, m.Get(TagRefSeqNum), m.Get(TagText))
+	case MsgTypeLogout:
+		s.sendLogout("")
+		return errLoggedOut
+	case MsgTypeLogon:
+		s.sendReject(m, 11, TagMsgType, "Already logged on")
+	case MsgTypeNewOrderSingle, MsgTypeOrderCancelRequest, MsgTypeOrderCancelReplace:
+		c.handleOrder(m)
+	default:
+		if isAdminMessage(m.MsgType()) {
+			s.sendReject(m, 11, TagMsgType, "Invalid MsgType")
+			return nil
+		}
+		_ = s.send(NewMessage(MsgTypeBusinessReject).
+			Set(TagRefSeqNum, m.Get(TagMsgSeqNum)).
+			Set(TagRefMsgType, m.MsgType()).
+			SetInt(TagBusinessRejectRsn, 3).
+			Set(TagText, "Unsupported message type"))
+	}
+	return nil
+}
+
+// handleSequenceReset 다음에 받을 번호를 NewSeqNo 로 변경 (번호를 줄이는 요청은 거절)
+func (c *connection) handleSequenceReset(m *Message) error {
+	s := c.session
+	newSeq, ok := m.GetInt(TagNewSeqNo)
+	if !ok || newSeq < s.store.NextTargetSeq() {
+		s.sendReject(m, 5, TagNewSeqNo, "Invalid NewSeqNo")
+		return nil
+	}
+	if err := s.store.SetNextTargetSeq(newSeq); err != nil {
+		log.Printf("Failed to store FIX sequence of %s: %v", s.targetCompID, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package fix
+
+import (
+	"bufio"
+	"net"
+	"testing"
+)
+
+func TestResendGapFill(t *testing.T) {
+	type resent struct {
+		seq      int
+		msgType  string
+		newSeqNo int // SequenceReset-GapFill 의 다음 번호
+	}
+
+	tests := []struct {
+		name       string
+		sent       []string // 보낸 메시지 종류 (번호 1부터)
+		begin, end int
+		want       []resent
+	}{
+		{
+			name:  "admin messages skipped",
+			sent:  []string{MsgTypeLogon, MsgTypeExecutionReport, MsgTypeHeartbeat, MsgTypeHeartbeat, MsgTypeExecutionReport},
+			begin: 1, end: 0,
+			want: []resent{{1, MsgTypeSequenceReset, 2}, {2, MsgTypeExecutionReport, 0}, {3, MsgTypeSequenceReset, 5}, {5, MsgTypeExecutionReport, 0}},
+		},
+		{
+			name:  "range in the middle",
+			sent:  []string{MsgTypeExecutionReport, MsgTypeExecutionReport, MsgTypeHeartbeat, MsgTypeExecutionReport},
+			begin: 2, end: 3,
+			want: []resent{{2, MsgTypeExecutionReport, 0}, {3, MsgTypeSequenceReset, 4}},
+		},
+		{
+			name:  "trailing admin message",
+			sent:  []string{MsgTypeExecutionReport, MsgTypeTestRequest},
+			begin: 1, end: 0,
+			want: []resent{{1, MsgTypeExecutionReport, 0}, {2, MsgTypeSequenceReset, 3}},
+		},
+		{
+			name:  "end past last message",
+			sent:  []string{MsgTypeExecutionReport},
+			begin: 1, end: 10,
+			want: []resent{{1, MsgTypeExecutionReport, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FIX_STORE_LOCATION", t.TempDir())
+			store, err := OpenStore("PJSE", "CLIENT", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &Session{senderCompID: "PJSE", targetCompID: "CLIENT", userID: 1, store: store}
+
+			// 연결이 끊긴 동안 보낸 메시지도 번호를 붙여 보관
+			for _, msgType := range tt.sent {
+				if err := s.send(NewMessage(msgType).Set(TagText, msgType)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			server, client := net.Pipe()
+			defer client.Close()
+			s.conn = server
+			done := make(chan error, 1)
+			go func() {
+				done <- s.resend(tt.begin, tt.end)
+				_ = server.Close()
+			}()
+
+			reader := bufio.NewReader(client)
+			var got []resent
+			for {
+				raw, err := ReadMessage(reader)
+				if err != nil {
+					break
+				}
+				m, err := ParseMessage(raw)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if m.Get(TagPossDupFlag) != "Y" {
+					t.Errorf("message %s resent without PossDupFlag", m.Get(TagMsgSeqNum))
+				}
+				seq, _ := m.GetInt(TagMsgSeqNum)
+				r := resent{seq: seq, msgType: m.MsgType()}
+				if r.msgType == MsgTypeSequenceReset {
+					if m.Get(TagGapFillFlag) != "Y" {
+						t.Errorf("sequence reset %d without GapFillFlag", seq)
+					}
+					r.newSeqNo, _ = m.GetInt(TagNewSeqNo)
+				} else if m.Get(TagOrigSendingTime) == "" {
+					t.Errorf("message %d resent without OrigSendingTime", seq)
+				}
+				got = append(got, r)
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("resend() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("resent %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("resent[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
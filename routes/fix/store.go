@@ -0,0 +1,203 @@
+package fix
+
+import (
+	"PJS_Exchange/utils"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// sessionState 세션별 다음 메시지 번호 (하루가 지나면 1부터 다시 시작)
+type sessionState struct {
+	UserID        int    `json:"user_id"`         // CompID 를 사용하는 사용자 (다른 사용자는 이 저장소로 로그온 불가)
+	Date          string `json:"date"`            // 번호를 시작한 날짜 (YYYY-MM-DD)
+	NextSenderSeq int    `json:"next_sender_seq"` // 다음에 보낼 메시지 번호
+	NextTargetSeq int    `json:"next_target_seq"` // 다음에 받을 메시지 번호
+}
+
+// storedMessage 재전송 요청에 대비해 보관하는 보낸 메시지
+type storedMessage struct {
+	Seq     int    `json:"seq"`
+	Message string `json:"message"`
+}
+
+// Store 세션별 메시지 번호와 보낸 메시지 저장소 (재접속 후에도 재전송 가능하도록 파일에 저장)
+type Store struct {
+	lock         sync.Mutex
+	statePath    string
+	messagesPath string
+	file         *os.File
+	state        sessionState
+	messages     map[int][]byte
+}
+
+func storeDir() string {
+	return utils.GetEnv("FIX_STORE_LOCATION", "./fix")
+}
+
+func today() string {
+	return time.Now().Format(time.DateOnly)
+}
+
+// ErrStoreOwner 다른 사용자가 사용하는 CompID 의 저장소를 열려는 경우
+var ErrStoreOwner = errors.New("CompID is used by another user")
+
+// OpenStore userID 사용자의 세션 저장소 열기 (저장된 날짜가 오늘이 아니면 번호 초기화)
+// 다른 사용자의 저장소면 ErrStoreOwner 반환, 사용자가 기록되지 않은 저장소는 보관한 메시지를 지우고 사용
+func OpenStore(senderCompID, targetCompID string, userID int) (*Store, error) {
+	dir := storeDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(senderCompID + "-" + targetCompID)
+	s := &Store{
+		statePath:    filepath.Join(dir, name+".seqnums.json"),
+		messagesPath: filepath.Join(dir, name+".messages"),
+		messages:     make(map[int][]byte),
+	}
+
+	data, err := os.ReadFile(s.statePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &s.state); err != nil {
+			return nil, err
+		}
+	}
+	if s.state.UserID != 0 && s.state.UserID != userID {
+		return nil, ErrStoreOwner
+	}
+	if s.state.UserID == 0 || s.state.Date != today() {
+		s.state.UserID = userID
+		if err := s.reset(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	if err := s.loadMessages(); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(s.messagesPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+func (s *Store) loadMessages() error {
+	file, err := os.Open(s.messagesPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*maxBodyLength)
+	for scanner.Scan() {
+		var msg storedMessage
+		// 마지막 줄이 기록 중 끊긴 경우 무시
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		s.messages[msg.Seq] = []byte(msg.Message)
+	}
+	return scanner.Err()
+}
+
+func (s *Store) saveState() error {
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+	tmp := s.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.statePath)
+}
+
+// Expired 오늘 시작한 번호가 아니면 true
+func (s *Store) Expired() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state.Date != today()
+}
+
+// Reset 메시지 번호를 1로 초기화하고 보관한 메시지 삭제
+func (s *Store) Reset() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.reset()
+}
+
+func (s *Store) reset() error {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	file, err := os.OpenFile(s.messagesPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.messages = make(map[int][]byte)
+	s.state = sessionState{UserID: s.state.UserID, Date: today(), NextSenderSeq: 1, NextTargetSeq: 1}
+	return s.saveState()
+}
+
+func (s *Store) NextSenderSeq() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state.NextSenderSeq
+}
+
+func (s *Store) NextTargetSeq() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state.NextTargetSeq
+}
+
+// SaveMessage 보낸 메시지를 보관하고 다음 보낼 번호 증가 (세션 관리 메시지는 message 를 nil 로 전달해 번호만 증가)
+func (s *Store) SaveMessage(seq int, message []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if message != nil {
+		data, err := json.Marshal(storedMessage{Seq: seq, Message: string(message)})
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+		if _, err := s.file.Write(data); err != nil {
+			return err
+		}
+		s.messages[seq] = message
+	}
+	s.state.NextSenderSeq = seq + 1
+	return s.saveState()
+}
+
+// SetNextTargetSeq 다음에 받을 번호 변경
+func (s *Store) SetNextTargetSeq(seq int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.state.NextTargetSeq = seq
+	return s.saveState()
+}
+
+// Message 보관한 메시지 반환 (세션 관리 메시지였거나 없으면 false)
+func (s *Store) Message(seq int) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	message, ok := s.messages[seq]
+	return message, ok
+}
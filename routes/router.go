@@ -85,6 +85,8 @@ func registerV1AdminRoutes(router fiber.Router) {
 		&v1admin.UserRouter{},
 		&v1admin.SymbolRouter{},
 		&v1admin.ActivationRouter{},
+		&v1admin.MarketRouter{},
+		&v1admin.OrderRouter{},
 		// 새로운 라우터가 추가되면 여기에 추가
 	}
 
@@ -113,7 +115,7 @@ func registerV1MarketRoutes(router fiber.Router) {
 func registerWebSocketRoutes(router fiber.Router) {
 	// 각 도메인의 라우터 인스턴스 생성 및 등록
 	routers := []RouteRegistrar{
-		//&ws.TradeRouter{},
+		&ws.TradeRouter{},
 		&ws.DepthRouter{},
 		&ws.LedgerRouter{},
 		&ws.NotifyRouter{},
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"PJS_Exchange/exchanges/channels"
+	"PJS_Exchange/protobuf/orders"
+	t "PJS_Exchange/template"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (es *exchangeServer) CreateOrder(ctx context.Context, req *orders.CreateOrderRequest) (*orders.OrderResponse, error) {
+	return submit(ctx, t.BatchOrderItem{
+		Action:              t.BatchActionNew,
+		Symbol:              req.Symbol,
+		Side:                req.Side,
+		ClientOrderID:       req.ClientOrderId,
+		OrderType:           req.Type,
+		Price:               t.Price(req.Price),
+		StopPrice:           t.Price(req.StopPrice),
+		Quantity:            int(req.Quantity),
+		TimeInForce:         req.TimeInForce,
+		ExpireAt:            req.ExpireAt,
+		PostOnly:            req.PostOnly,
+		DisplayQuantity:     int(req.DisplayQuantity),
+		SelfTradePrevention: req.SelfTradePrevention,
+	})
+}
+
+func (es *exchangeServer) ModifyOrder(ctx context.Context, req *orders.ModifyOrderRequest) (*orders.OrderResponse, error) {
+	return submit(ctx, t.BatchOrderItem{
+		Action:        t.BatchActionModify,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		OrderID:       req.OrderId,
+		ClientOrderID: req.ClientOrderId,
+		OrderType:     req.Type,
+		Price:         t.Price(req.Price),
+		StopPrice:     t.Price(req.StopPrice),
+		Quantity:      int(req.Quantity),
+	})
+}
+
+func (es *exchangeServer) CancelOrder(ctx context.Context, req *orders.CancelOrderRequest) (*orders.OrderResponse, error) {
+	return submit(ctx, t.BatchOrderItem{
+		Action:        t.BatchActionCancel,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		OrderID:       req.OrderId,
+		ClientOrderID: req.ClientOrderId,
+	})
+}
+
+// submit 주문 요청을 매칭 엔진에 전달하고 처리 결과를 기다림 (거절도 응답의 success, code 로 반환)
+func submit(ctx context.Context, item t.BatchOrderItem) (*orders.OrderResponse, error) {
+	user, apiKey := userFromContext(ctx)
+	if channels.OP == nil {
+		return nil, status.Error(codes.Unavailable, "Order entry is not available")
+	}
+
+	orderReq, result, ok := channels.OP.SubmitItem(ctx, item, user, apiKey, nil)
+	if ok {
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if result, ok = channels.WaitResult(waitCtx, orderReq.ResultChan); !ok {
+			result = t.Result{
+				Timestamp: time.Now().UnixMilli(),
+				Message:   "Order processing is busy, wait for the execution report",
+				Code:      503,
+			}
+		}
+	}
+
+	return &orders.OrderResponse{
+		Success:       result.Success,
+		OrderId:       orderReq.OrderID,
+		ClientOrderId: item.ClientOrderID,
+		Code:          int32(result.Code),
+		Message:       result.Message,
+		ReasonCode:    result.ReasonCode,
+		Timestamp:     result.Timestamp,
+	}, nil
+}
+
+func (es *exchangeServer) GetOpenOrders(ctx context.Context, req *orders.OpenOrdersRequest) (*orders.OpenOrders, error) {
+	user, _ := userFromContext(ctx)
+	if channels.OP == nil {
+		return nil, status.Error(codes.Unavailable, "Order entry is not available")
+	}
+
+	var openOrders []t.OpenOrder
+	if req.Symbol == "" {
+		openOrders = channels.OP.GetAllOpenOrders(user.ID)
+	} else {
+		openOrders = channels.OP.GetOpenOrders(req.Symbol, user.ID)
+	}
+
+	response := &orders.OpenOrders{Orders: make([]*orders.OpenOrder, 0, len(openOrders))}
+	for _, order := range openOrders {
+		response.Orders = append(response.Orders, orders.FromOpenOrder(order))
+	}
+	return response, nil
+}
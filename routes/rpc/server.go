@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"PJS_Exchange/app/postgresApp"
+	"PJS_Exchange/databases/postgresql"
+	pb "PJS_Exchange/protobuf/exchange"
+	"PJS_Exchange/utils"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey int
+
+const (
+	userKey contextKey = iota
+	apiKeyKey
+)
+
+// methodScopes 메서드별 필요한 API 키 권한 (REST API, WebSocket 과 같음)
+var methodScopes = map[string]postgresql.APIKeyScope{
+	pb.Exchange_CreateOrder_FullMethodName:            {OrderCreate: true},
+	pb.Exchange_ModifyOrder_FullMethodName:            {OrderModify: true},
+	pb.Exchange_CancelOrder_FullMethodName:            {OrderCancel: true},
+	pb.Exchange_GetOpenOrders_FullMethodName:          {OrderRead: true},
+	pb.Exchange_StreamExecutionReports_FullMethodName: {OrderNotify: true},
+	pb.Exchange_StreamDepth_FullMethodName:            {MarketDataRead: true},
+	pb.Exchange_StreamTrades_FullMethodName:           {MarketDataRead: true},
+	pb.Exchange_StreamSessionStatus_FullMethodName:    {},
+}
+
+// Enabled GRPC_PORT 가 설정된 경우에만 gRPC API 사용
+func Enabled() bool {
+	return utils.GetEnv("GRPC_PORT", "") != ""
+}
+
+// Start Fiber 와 별도의 포트로 gRPC API 서버 시작 (서버가 종료되면 반환)
+func Start() error {
+	listener, err := net.Listen("tcp", ":"+utils.GetEnv("GRPC_PORT", ""))
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAuth),
+		grpc.StreamInterceptor(streamAuth),
+	)
+	pb.RegisterExchangeServer(server, &exchangeServer{})
+	return server.Serve(listener)
+}
+
+// authenticate 메타데이터의 authorization: Bearer {API_KEY} 로 인증 후 메서드 권한 확인 (APIKeyMiddlewareRequireScopes 와 같음)
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	scope, ok := methodScopes[method]
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "Unknown method")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "Missing authorization metadata")
+	}
+	token := strings.TrimPrefix(values[0], "Bearer ")
+
+	apiKey, err := postgresApp.Get().APIKeyRepo().AuthenticateAPIKey(ctx, token)
+	if err != nil || apiKey == nil || apiKey.Status != "active" {
+		return nil, status.Error(codes.Unauthenticated, "API key authentication failed")
+	}
+	if !postgresql.IsinScope(apiKey.Scopes, scope) {
+		return nil, status.Error(codes.PermissionDenied, "Insufficient scope")
+	}
+
+	// 활성화된 계정 인지 확인
+	id, err := strconv.Atoi(apiKey.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Invalid user ID format")
+	}
+	user, err := postgresApp.Get().UserRepo().GetUserByID(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to retrieve user")
+	}
+	if user == nil || !user.Enabled {
+		return nil, status.Error(codes.Unauthenticated, "User account is not enabled")
+	}
+
+	ctx = context.WithValue(ctx, userKey, user)
+	return context.WithValue(ctx, apiKeyKey, apiKey), nil
+}
+
+func unaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authenticatedStream 인증 정보를 담은 context 를 반환하는 스트림
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func streamAuth(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+func userFromContext(ctx context.Context) (*postgresql.User, *postgresql.APIKey) {
+	user, _ := ctx.Value(userKey).(*postgresql.User)
+	apiKey, _ := ctx.Value(apiKeyKey).(*postgresql.APIKey)
+	return user, apiKey
+}
+
+type exchangeServer struct {
+	pb.UnimplementedExchangeServer
+}
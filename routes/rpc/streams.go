@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"PJS_Exchange/app"
+	"PJS_Exchange/exchanges"
+	"PJS_Exchange/protobuf/market"
+	"PJS_Exchange/protobuf/orders"
+	"PJS_Exchange/routes/ws"
+	t "PJS_Exchange/template"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const streamBufferSize = 1024
+
+// streamHub WebSocket 허브의 메시지 원본을 변환해서 스트림으로 전송 (convert 가 false 를 반환하면 건너뜀)
+// 클라이언트가 연결을 끊거나, 메시지를 제때 읽지 않거나, 장 종료 후 허브 연결이 모두 끊기면 반환
+func streamHub[T any](stream grpc.ServerStreamingServer[T], hub *app.WSHub, userID int, req *market.StreamRequest, convert func(value any) (*T, bool)) error {
+	since := int64(-1)
+	if req.Since != nil {
+		since = *req.Since
+	}
+	messages, unsubscribe := hub.Subscribe(userID, since, streamBufferSize)
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-messages:
+			if !ok {
+				return status.Error(codes.Unavailable, "Stream closed, please subscribe again")
+			}
+			converted, ok := convert(msg.Payload.Value)
+			if !ok {
+				continue
+			}
+			if err := stream.Send(converted); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (es *exchangeServer) StreamExecutionReports(req *market.StreamRequest, stream grpc.ServerStreamingServer[orders.ExecutionReport]) error {
+	user, _ := userFromContext(stream.Context())
+	return streamHub(stream, ws.NotifyHub, user.ID, req, func(value any) (*orders.ExecutionReport, bool) {
+		report, ok := value.(t.ExecutionReport)
+		if !ok || (req.Symbol != "" && report.Symbol != req.Symbol) {
+			return nil, false
+		}
+		return orders.FromExecutionReport(report), true
+	})
+}
+
+func (es *exchangeServer) StreamDepth(req *market.StreamRequest, stream grpc.ServerStreamingServer[market.DepthUpdate]) error {
+	return streamHub(stream, ws.DepthHub, 0, req, func(value any) (*market.DepthUpdate, bool) {
+		// 호가 갱신과 단일가 매매 예상 체결가가 같은 허브로 전송됨
+		switch data := value.(type) {
+		case t.UpdateDepth:
+			if req.Symbol != "" && data.Symbol != req.Symbol {
+				return nil, false
+			}
+			return market.FromUpdateDepth(data), true
+		case t.AuctionIndicative:
+			if req.Symbol != "" && data.Symbol != req.Symbol {
+				return nil, false
+			}
+			return market.FromAuctionIndicative(data), true
+		}
+		return nil, false
+	})
+}
+
+func (es *exchangeServer) StreamTrades(req *market.StreamRequest, stream grpc.ServerStreamingServer[market.Trade]) error {
+	return streamHub(stream, ws.LedgerHub, 0, req, func(value any) (*market.Trade, bool) {
+		ledger, ok := value.(t.Ledger)
+		if !ok || (req.Symbol != "" && ledger.Symbol != req.Symbol) {
+			return nil, false
+		}
+		return market.FromLedger(ledger), true
+	})
+}
+
+// StreamSessionStatus 현재 세션 상태를 먼저 보내고 이후 변경 사항 전송 (/ws/session 과 같음, since 와 symbol 은 무시)
+func (es *exchangeServer) StreamSessionStatus(req *market.StreamRequest, stream grpc.ServerStreamingServer[market.SessionStatus]) error {
+	current := t.SessionStatus{
+		Session: exchanges.MarketStatus,
+	}
+	if halt := exchanges.GetHalt(); halt.Halted {
+		current = t.SessionStatus{
+			Session:  "halt",
+			Reason:   halt.Reason,
+			ResumeAt: halt.ResumeAt,
+		}
+	}
+	if err := stream.Send(market.FromSessionStatus(current)); err != nil {
+		return err
+	}
+
+	return streamHub(stream, ws.SessionHub, 0, &market.StreamRequest{}, func(value any) (*market.SessionStatus, bool) {
+		sessionStatus, ok := value.(t.SessionStatus)
+		if !ok {
+			return nil, false
+		}
+		return market.FromSessionStatus(sessionStatus), true
+	})
+}
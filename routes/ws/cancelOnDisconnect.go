@@ -0,0 +1,94 @@
+package ws
+
+import (
+	"PJS_Exchange/utils"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// cancelUserOrders 사용자의 미체결 주문 일괄 취소 후 취소된 주문 수 반환 (ws 패키지는 매칭 엔진을 import 할 수 없어 시작 시 등록)
+	cancelUserOrders func(userID int) int
+
+	disconnectTimers = make(map[int]*time.Timer) // 사용자별 주문 취소 대기 타이머
+	disconnectedAt   = make(map[int]int64)       // 연결 종료로 주문이 취소된 사용자의 연결 종료 시각 (재접속 시 이후 알림 전송)
+	disconnectLock   sync.Mutex
+)
+
+// SetCancelOnDisconnectHandler 연결 종료 시 호출할 주문 일괄 취소 함수 등록
+func SetCancelOnDisconnectHandler(handler func(userID int) int) {
+	disconnectLock.Lock()
+	defer disconnectLock.Unlock()
+	cancelUserOrders = handler
+}
+
+// cancelOnDisconnectGrace 마지막 알림 연결이 끊긴 후 주문을 취소하기까지 재접속을 기다리는 시간
+func cancelOnDisconnectGrace() time.Duration {
+	sec, err := strconv.Atoi(utils.GetEnv("CANCEL_ON_DISCONNECT_GRACE_SECONDS", "10"))
+	if err != nil || sec < 0 {
+		sec = 10
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// scheduleCancelOnDisconnect 남은 알림 연결이 없으면 유예 시간 후 주문 취소 예약 (그 사이 재접속하면 취소하지 않음)
+func scheduleCancelOnDisconnect(userID int, username string) {
+	disconnectLock.Lock()
+	defer disconnectLock.Unlock()
+
+	if cancelUserOrders == nil || NotifyHub.HasClient(userID) {
+		return
+	}
+	if timer, ok := disconnectTimers[userID]; ok {
+		timer.Stop()
+	}
+
+	since := time.Now().UnixMilli()
+	var timer *time.Timer
+	timer = time.AfterFunc(cancelOnDisconnectGrace(), func() {
+		disconnectLock.Lock()
+		if disconnectTimers[userID] != timer || NotifyHub.HasClient(userID) {
+			disconnectLock.Unlock()
+			return
+		}
+		delete(disconnectTimers, userID)
+		cancel := cancelUserOrders
+		disconnectLock.Unlock()
+
+		canceled := cancel(userID)
+		log.Printf("Canceled %d orders of user %s on notify disconnect", canceled, username)
+		if canceled == 0 {
+			return
+		}
+
+		disconnectLock.Lock()
+		if _, ok := disconnectedAt[userID]; !ok {
+			disconnectedAt[userID] = since
+		}
+		disconnectLock.Unlock()
+	})
+	disconnectTimers[userID] = timer
+}
+
+// resumeCancelOnDisconnect 재접속 시 대기 중인 주문 취소를 멈추고, 이미 취소된 경우 연결 종료 시각 이후 알림부터 받도록 since 조정
+func resumeCancelOnDisconnect(userID int, since string) string {
+	disconnectLock.Lock()
+	defer disconnectLock.Unlock()
+
+	if timer, ok := disconnectTimers[userID]; ok {
+		timer.Stop()
+		delete(disconnectTimers, userID)
+	}
+
+	ts, ok := disconnectedAt[userID]
+	if !ok {
+		return since
+	}
+	delete(disconnectedAt, userID)
+	if since == "-1" {
+		return strconv.FormatInt(ts, 10)
+	}
+	return since
+}
@@ -4,32 +4,21 @@ import (
 	"PJS_Exchange/app"
 	"PJS_Exchange/databases/postgresql"
 	"PJS_Exchange/middlewares/auth"
-	"PJS_Exchange/template"
-	"PJS_Exchange/utils"
 	"context"
 	"log"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
-	"github.com/google/btree"
 	"github.com/google/uuid"
 )
 
 var (
-	DepthHub               = app.NewWSHub(false)
-	TempDepth              = make(map[string]template.MarketDepth)                        // 심볼별 임시 호가 데이터 저장용 (예: "NVDA" : {Bids: [...], Asks: [...]})
-	TempDepthOrderIDIndex  = make(map[string]map[string][]interface{})                    // 심볼별 주문 ID 인덱스 (예: "NVDA" : {"orderID1": [1, "bid", 123.45, 10, 1], "orderID2": [2, "ask", 678.90, 20, 1]}
-	TempDepthExecutionSeq  = make(map[string]map[string]map[float64]*utils.Queue[string]) // 심볼별 가격대별 주문 순서 ID 리스트 (예: "NVDA" : {"bids": {123.45: ["orderID1", "orderID3"], 678.90: ["orderID2"]}, "asks": {123.45: ["orderID4"], 678.90: ["orderID5", "orderID6"]}})
-	TempBidAskOverlapCheck = make(map[string]*btree.BTree)                                // 심볼별 매수/매도 가격 중복 체크용 (예: "NVDA" : btree.BTree{}
+	DepthHub = app.NewWSHub(false) // 심볼별 호가 데이터는 각 심볼의 매칭 엔진(channels.SymbolShard)이 보유
 )
 
 func ClearTempDepthData() {
 	DepthHub.ClearMessages()
-	TempDepth = make(map[string]template.MarketDepth)
-	TempDepthOrderIDIndex = make(map[string]map[string][]interface{})
-	TempDepthExecutionSeq = make(map[string]map[string]map[float64]*utils.Queue[string])
-	TempBidAskOverlapCheck = make(map[string]*btree.BTree)
 }
 
 type DepthRouter struct{}
@@ -39,16 +28,16 @@ func (dr *DepthRouter) RegisterRoutes(router fiber.Router) {
 		MarketDataRead: true,
 	}))
 
-	depthGroup.Get("/", websocket.New(dr.handleDepth))
+	depthGroup.Get("/", EncodingMiddleware, websocket.New(dr.handleDepth, upgradeConfig))
 	//depthGroup.Get("/:sym", websocket.New(dr.handleSelDepth))
 }
 
-// TODO 추후 protobuf로 변경
 // @summary		Depth WebSocket
-// @description	일일 실시간 호가 데이터를 WebSocket을 통해 구독합니다.
+// @description	일일 실시간 호가 데이터를 WebSocket을 통해 구독합니다. 단일가 매매(프리장, 포스트장) 중에는 side 가 "auction" 인 예상 체결가 메시지(price, quantity, imbalance)도 함께 전송됩니다.
 // @tags		WebSocket
 // @produce		json
 // @param		since	query	string	false	"특정 타임스탬프 이후의 데이터를 받기 위한 옵션 (0을 입력하면 오늘 발생한 전체 데이터 수신)"
+// @param		encoding	query	string	false	"메시지 인코딩 (json: 텍스트 프레임 (기본값), protobuf: 바이너리 프레임, Sec-WebSocket-Protocol 로도 선택 가능)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
 // @success		200	{string}	string	"WebSocket 연결 성공 및 구독 시작 메시지"
 // @failure		400	{object}	map[string]string	"잘못된 요청"
@@ -64,6 +53,7 @@ func (dr *DepthRouter) handleDepth(c *websocket.Conn) {
 		ConnID:   uuid.NewString(),
 		Username: user.Username,
 		Conn:     c,
+		Encoding: messageEncoding(c),
 		Syncing:  since != "-1",
 	}
 
@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"PJS_Exchange/app"
+	"PJS_Exchange/protobuf/market"
+	"PJS_Exchange/protobuf/orders"
+	"PJS_Exchange/template"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// upgradeConfig 메시지 인코딩을 Sec-WebSocket-Protocol 로도 선택할 수 있도록 서브프로토콜 협상
+var upgradeConfig = websocket.Config{
+	Subprotocols: []string{app.EncodingJSON, app.EncodingProtobuf},
+}
+
+// EncodingMiddleware encoding 쿼리 파라미터 검증 (json, protobuf 외의 값이면 업그레이드 전에 400 반환)
+func EncodingMiddleware(c *fiber.Ctx) error {
+	switch c.Query("encoding") {
+	case "", app.EncodingJSON, app.EncodingProtobuf:
+		return c.Next()
+	default:
+		return template.ErrorHandler(c, fiber.StatusBadRequest, "Invalid encoding value")
+	}
+}
+
+// messageEncoding 연결의 메시지 인코딩 (encoding 쿼리 파라미터 > 서브프로토콜 > JSON 순)
+func messageEncoding(c *websocket.Conn) string {
+	if encoding := c.Query("encoding"); encoding != "" {
+		return encoding
+	}
+	if c.Subprotocol() == app.EncodingProtobuf {
+		return app.EncodingProtobuf
+	}
+	return app.EncodingJSON
+}
+
+// DepthPayload 호가 갱신 메시지
+func DepthPayload(depth template.UpdateDepth) *app.Payload {
+	return app.NewPayload(depth, func() proto.Message {
+		return market.FromUpdateDepth(depth)
+	})
+}
+
+// AuctionPayload 단일가 매매 예상 체결가 메시지 (DepthHub 로 전송)
+func AuctionPayload(indicative template.AuctionIndicative) *app.Payload {
+	return app.NewPayload(indicative, func() proto.Message {
+		return market.FromAuctionIndicative(indicative)
+	})
+}
+
+// LedgerPayload 체결 메시지
+func LedgerPayload(ledger template.Ledger) *app.Payload {
+	return app.NewPayload(ledger, func() proto.Message {
+		return market.FromLedger(ledger)
+	})
+}
+
+// NotifyPayload 체결 통보 메시지
+func NotifyPayload(report template.ExecutionReport) *app.Payload {
+	return app.NewPayload(report, func() proto.Message {
+		return orders.FromExecutionReport(report)
+	})
+}
+
+// SessionPayload 세션 상태 메시지
+func SessionPayload(status template.SessionStatus) *app.Payload {
+	return app.NewPayload(status, func() proto.Message {
+		return market.FromSessionStatus(status)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"PJS_Exchange/utils"
 	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,13 +17,36 @@ import (
 )
 
 var (
-	LedgerHub  = app.NewWSHub(false)
-	TempLedger = make(map[string]*utils.ChunkedStore[template.Ledger]) // 심볼별 임시 원장 데이터 저장용 (예: "NVDA" : [{Timestamp: ..., Price: ..., Volume: ...}, ...])
+	LedgerHub      = app.NewWSHub(false)
+	TempLedger     = make(map[string]*utils.ChunkedStore[template.Ledger]) // 심볼별 임시 원장 데이터 저장용 (예: "NVDA" : [{Timestamp: ..., Price: ..., Volume: ...}, ...])
+	tempLedgerLock sync.RWMutex                                            // 여러 심볼의 매칭 엔진이 동시에 접근하므로 TempLedger는 아래 함수로만 접근
 )
 
 func ClearTempLedgerData() {
 	LedgerHub.ClearMessages()
+	tempLedgerLock.Lock()
 	TempLedger = make(map[string]*utils.ChunkedStore[template.Ledger])
+	tempLedgerLock.Unlock()
+}
+
+// GetTempLedger 심볼의 임시 원장 반환 (없으면 nil)
+func GetTempLedger(symbol string) *utils.ChunkedStore[template.Ledger] {
+	tempLedgerLock.RLock()
+	defer tempLedgerLock.RUnlock()
+	return TempLedger[symbol]
+}
+
+// AppendTempLedger 심볼의 임시 원장에 체결 내역 추가
+func AppendTempLedger(ledger template.Ledger) {
+	tempLedgerLock.Lock()
+	store := TempLedger[ledger.Symbol]
+	if store == nil {
+		store = utils.NewChunkedStore[template.Ledger](128)
+		TempLedger[ledger.Symbol] = store
+	}
+	tempLedgerLock.Unlock()
+
+	store.Append(ledger)
 }
 
 type LedgerRouter struct{}
@@ -32,15 +56,15 @@ func (lr *LedgerRouter) RegisterRoutes(router fiber.Router) {
 		MarketDataRead: true,
 	}))
 
-	ledgerGroup.Get("/", websocket.New(lr.handleLedger))
+	ledgerGroup.Get("/", EncodingMiddleware, websocket.New(lr.handleLedger, upgradeConfig))
 }
 
-// TODO 추후 protobuf로 변경
 // @summary		Ledger WebSocket
 // @description	일일 실시간 체결 데이터를 WebSocket을 통해 구독합니다.
 // @tags		WebSocket
 // @produce		json
 // @param		since	query	string	false	"특정 타임스탬프 이후의 데이터를 받기 위한 옵션 (0을 입력하면 오늘 발생한 전체 데이터 수신)"
+// @param		encoding	query	string	false	"메시지 인코딩 (json: 텍스트 프레임 (기본값), protobuf: 바이너리 프레임, Sec-WebSocket-Protocol 로도 선택 가능)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
 // @success		200	{string}	string	"WebSocket 연결 성공 및 구독 시작 메시지"
 // @failure		400	{object}	map[string]string	"잘못된 요청"
@@ -56,6 +80,7 @@ func (lr *LedgerRouter) handleLedger(c *websocket.Conn) {
 		ConnID:   uuid.NewString(),
 		Username: user.Username,
 		Conn:     c,
+		Encoding: messageEncoding(c),
 		Syncing:  since != "-1",
 	}
 
@@ -28,15 +28,15 @@ func (nr *NotifyRouter) RegisterRoutes(router fiber.Router) {
 		OrderNotify: true,
 	}))
 
-	notifyGroup.Get("/", websocket.New(nr.handleNotify))
+	notifyGroup.Get("/", EncodingMiddleware, websocket.New(nr.handleNotify, upgradeConfig))
 }
 
-// TODO 추후 protobuf로 변경
 // @summary		Notify WebSocket
-// @description	일일 실시간 알림 데이터를 WebSocket을 통해 구독합니다.
+// @description	일일 실시간 알림 데이터(주문 접수, 정정, 취소, 거절, 체결 실행 보고서)를 WebSocket을 통해 구독합니다. 실행 보고서의 seq는 사용자별로 1씩 증가하므로 누락 여부를 확인할 수 있습니다. API 키에 cancel_on_disconnect가 설정된 경우 마지막 연결이 끊기고 유예 시간 안에 재접속하지 않으면 미체결 주문이 모두 취소되며, 재접속 시 취소 알림부터 전송됩니다.
 // @tags		WebSocket
 // @produce		json
 // @param		since	query	string	false	"특정 타임스탬프 이후의 데이터를 받기 위한 옵션 (0을 입력하면 오늘 발생한 전체 데이터 수신)"
+// @param		encoding	query	string	false	"메시지 인코딩 (json: 텍스트 프레임 (기본값), protobuf: 바이너리 프레임, Sec-WebSocket-Protocol 로도 선택 가능)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
 // @success		200	{string}	string	"WebSocket 연결 성공 및 구독 시작 메시지"
 // @failure		400	{object}	map[string]string	"잘못된 요청"
@@ -44,14 +44,18 @@ func (nr *NotifyRouter) RegisterRoutes(router fiber.Router) {
 // @failure		500	{object}	map[string]string	"서버 오류"
 // @router		/ws/notify [get]
 func (nr *NotifyRouter) handleNotify(c *websocket.Conn) {
-	since := c.Query("since", "-1")
 	user := c.Locals("user").(*postgresql.User)
+	apiKey, _ := c.Locals("apiKey").(*postgresql.APIKey)
+
+	// 연결 종료로 주문이 취소된 뒤 재접속한 경우 since 를 주지 않아도 취소 알림부터 전송
+	since := resumeCancelOnDisconnect(user.ID, c.Query("since", "-1"))
 
 	client := &app.Client{
 		ID:       user.ID,
 		ConnID:   uuid.NewString(),
 		Username: user.Username,
 		Conn:     c,
+		Encoding: messageEncoding(c),
 		Syncing:  since != "-1",
 	}
 
@@ -66,6 +70,9 @@ func (nr *NotifyRouter) handleNotify(c *websocket.Conn) {
 	defer func() {
 		NotifyHub.UnregisterClient(client)
 		log.Printf("User %s unsubscribed from notify updates", user.Username)
+		if apiKey != nil && apiKey.CancelOnDisconnect {
+			scheduleCancelOnDisconnect(user.ID, user.Username)
+		}
 	}()
 
 	// PING/PONG 관리용 고루틴
@@ -7,7 +7,6 @@ import (
 	"PJS_Exchange/middlewares/auth"
 	"PJS_Exchange/template"
 	"context"
-	"encoding/json"
 	"log"
 	"time"
 
@@ -25,14 +24,15 @@ type SessionRouter struct{}
 func (sr *SessionRouter) RegisterRoutes(router fiber.Router) {
 	SessionGroup := router.Group("/session")
 
-	SessionGroup.Get("/", auth.APIKeyMiddleware(auth.Config{Bypass: false}), websocket.New(sr.handleStatus))
+	SessionGroup.Get("/", auth.APIKeyMiddleware(auth.Config{Bypass: false}), EncodingMiddleware, websocket.New(sr.handleStatus, upgradeConfig))
 }
 
-// TODO 추후 protobuf로 변경
 // @summary		Session WebSocket
 // @description	실시간 세션 상태 데이터를 WebSocket을 통해 구독합니다.
+// @description	거래 중단 (서킷 브레이커) 시 halt (reason, resume_at 포함), 재개 5분 전, 1분 전 resume-5m, resume-1m, 재개 시 resume 메시지를 보냅니다.
 // @tags		WebSocket
 // @produce		json
+// @param		encoding	query	string	false	"메시지 인코딩 (json: 텍스트 프레임 (기본값), protobuf: 바이너리 프레임, Sec-WebSocket-Protocol 로도 선택 가능)"
 // @Param			Authorization	header		string				true	"Bearer {API_KEY}"
 // @success		200	{string}	string	"WebSocket 연결 성공 및 구독 시작 메시지"
 // @failure		400	{object}	map[string]string	"잘못된 요청"
@@ -46,6 +46,7 @@ func (sr *SessionRouter) handleStatus(c *websocket.Conn) {
 		ConnID:   uuid.NewString(),
 		Username: user.Username,
 		Conn:     c,
+		Encoding: messageEncoding(c),
 		Syncing:  false,
 	}
 
@@ -55,15 +56,18 @@ func (sr *SessionRouter) handleStatus(c *websocket.Conn) {
 		writeWait    = 10 * time.Second // 쓰기 대기 시간
 	)
 
-	// 초기 세션 상태 전송
-	session, err := json.Marshal(template.SessionStatus{
+	// 초기 세션 상태 전송 (거래 중단 중이면 halt)
+	status := template.SessionStatus{
 		Session: exchanges.MarketStatus,
-	})
-	if err != nil {
-		log.Printf("Failed to marshal session status for user %s: %v", user.Username, err)
-		return
 	}
-	err = c.WriteMessage(websocket.TextMessage, session)
+	if halt := exchanges.GetHalt(); halt.Halted {
+		status = template.SessionStatus{
+			Session:  "halt",
+			Reason:   halt.Reason,
+			ResumeAt: halt.ResumeAt,
+		}
+	}
+	err := client.WritePayload(SessionPayload(status))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,192 @@
+package ws
+
+import (
+	"PJS_Exchange/app"
+	"PJS_Exchange/databases/postgresql"
+	"PJS_Exchange/middlewares/auth"
+	"PJS_Exchange/template"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+)
+
+var (
+	TradeHub = app.NewWSHub(true)
+
+	// submitOrder 주문 요청을 검증해서 매칭 엔진에 전달 (ws 패키지는 매칭 엔진을 import 할 수 없어 시작 시 등록)
+	submitOrder OrderSubmitter
+)
+
+// OrderSubmitter 주문 요청을 매칭 엔진에 전달하고 전달된 요청을 반환 (전달하지 못하면 거절 결과와 false)
+type OrderSubmitter func(ctx context.Context, item template.BatchOrderItem, user *postgresql.User, apiKey *postgresql.APIKey) (*template.OrderRequest, template.Result, bool)
+
+// SetOrderSubmitter 거래 WebSocket 에서 사용할 주문 전달 함수 등록
+func SetOrderSubmitter(submitter OrderSubmitter) {
+	submitOrder = submitter
+}
+
+func ClearTempTradeData() {
+	TradeHub.ClearMessages()
+}
+
+const maxTradeMessageSize = 64 * 1024
+
+type TradeRouter struct{}
+
+func (tr *TradeRouter) RegisterRoutes(router fiber.Router) {
+	tradeGroup := router.Group("/trade", auth.APIKeyMiddleware(auth.Config{Bypass: false}))
+
+	tradeGroup.Get("/", websocket.New(tr.handleTrade))
+}
+
+// TODO 추후 protobuf로 변경
+// @summary		Trade WebSocket
+// @description	하나의 WebSocket으로 주문을 접수하고 결과를 받습니다. 요청은 {"id": "요청 ID", "action": "new" | "modify" | "cancel", ...} 형식이며 action 이외의 필드는 일괄 주문(template.BatchOrderItem)과 같습니다.
+// @description	각 요청마다 매칭 엔진의 처리 결과를 {"event": "ack" | "reject", "id": "요청 ID", ...} (template.TradeResponse)로 보내고, API 키에 order:notify 권한이 있으면 /ws/notify 와 같은 실행 보고서(event 없음)도 함께 보냅니다. (ack와 실행 보고서의 순서는 보장되지 않음)
+// @description	요청마다 API 키에 해당 권한(order:create, order:modify, order:cancel)이 있어야 하며, 같은 연결에서 보낸 요청은 보낸 순서대로 매칭 엔진에 전달됩니다.
+// @tags		WebSocket
+// @produce		json
+// @Param			Authorization	header		string				true	"Bearer {API_KEY}"
+// @success		200	{string}	string	"WebSocket 연결 성공"
+// @failure		400	{object}	map[string]string	"잘못된 요청"
+// @failure		401	{object}	map[string]string	"인증 실패"
+// @failure		500	{object}	map[string]string	"서버 오류"
+// @router		/ws/trade [get]
+func (tr *TradeRouter) handleTrade(c *websocket.Conn) {
+	user := c.Locals("user").(*postgresql.User)
+	apiKey := c.Locals("apiKey").(*postgresql.APIKey)
+
+	client := &app.Client{
+		ID:       user.ID,
+		ConnID:   uuid.NewString(),
+		Username: user.Username,
+		Conn:     c,
+		Syncing:  false,
+	}
+
+	const (
+		pingInterval = 20 * time.Second // 30초마다 PING
+		pongTimeout  = 40 * time.Second // 60초 타임아웃
+		writeWait    = 10 * time.Second // 쓰기 대기 시간
+	)
+
+	// 실행 보고서는 order:notify 권한이 있는 경우에만 전송
+	if apiKey.Scopes.OrderNotify {
+		TradeHub.RegisterClient(client)
+		defer TradeHub.UnregisterClient(client)
+	}
+	log.Printf("User %s connected to trade", user.Username)
+	defer log.Printf("User %s disconnected from trade", user.Username)
+
+	// PING/PONG 관리용 고루틴
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.WriteControl(websocket.PingMessage, []byte("heartbeat"), time.Now().Add(writeWait)); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c.SetReadLimit(maxTradeMessageSize)
+	if err := c.SetReadDeadline(time.Now().Add(pongTimeout)); err != nil {
+		return
+	}
+	c.SetPongHandler(func(appData string) error {
+		return c.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	for {
+		_, data, err := c.ReadMessage()
+		if err != nil {
+			break
+		}
+		tr.handleTradeRequest(ctx, client, user, apiKey, data)
+	}
+}
+
+// handleTradeRequest 요청은 순서대로 매칭 엔진에 전달하고, 결과는 기다리지 않고 도착하면 응답
+func (tr *TradeRouter) handleTradeRequest(ctx context.Context, client *app.Client, user *postgresql.User, apiKey *postgresql.APIKey, data []byte) {
+	var request template.TradeRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		sendTradeResponse(client, template.TradeResponse{
+			Event: template.TradeEventReject,
+			Code:  fiber.StatusBadRequest,
+			Error: "Invalid message",
+		})
+		return
+	}
+
+	response := template.TradeResponse{
+		Event:         template.TradeEventReject,
+		RequestID:     request.RequestID,
+		Action:        request.Action,
+		Symbol:        request.Symbol,
+		Side:          request.Side,
+		OrderID:       request.OrderID,
+		ClientOrderID: request.ClientOrderID,
+	}
+	if submitOrder == nil {
+		response.Code = fiber.StatusServiceUnavailable
+		response.Error = "Order entry is not available"
+		sendTradeResponse(client, response)
+		return
+	}
+
+	orderReq, result, ok := submitOrder(ctx, request.BatchOrderItem, user, apiKey)
+	response.OrderID = orderReq.OrderID
+	if !ok {
+		response.Code = result.Code
+		response.Error = result.Message
+		response.ReasonCode = result.ReasonCode
+		sendTradeResponse(client, response)
+		return
+	}
+
+	go func() {
+		timer := time.NewTimer(5 * time.Second)
+		defer timer.Stop()
+
+		select {
+		case result := <-orderReq.ResultChan:
+			response.Code = result.Code
+			if result.Success {
+				response.Event = template.TradeEventAck
+			} else {
+				response.Error = result.Message
+				response.ReasonCode = result.ReasonCode
+			}
+		case <-timer.C:
+			response.Code = fiber.StatusServiceUnavailable
+			response.Error = "Order processing is busy, wait for the execution report"
+		}
+		sendTradeResponse(client, response)
+	}()
+}
+
+func sendTradeResponse(client *app.Client, response template.TradeResponse) {
+	response.Timestamp = time.Now().UnixMilli()
+	message, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("Error marshaling TradeResponse: %v", err)
+		return
+	}
+	if err := client.WriteMessage(websocket.TextMessage, message); err != nil {
+		log.Printf("Failed to send trade response to user %s: %v", client.Username, err)
+	}
+}
@@ -6,11 +6,11 @@ import (
 
 /* B-Tree */
 
-type Float64Item float64
+type PriceItem Price
 type StringItem string
 
-func (a Float64Item) Less(b btree.Item) bool {
-	return a < b.(Float64Item)
+func (a PriceItem) Less(b btree.Item) bool {
+	return a < b.(PriceItem)
 }
 func (a StringItem) Less(b btree.Item) bool {
 	return a < b.(StringItem)
@@ -18,9 +18,15 @@ func (a StringItem) Less(b btree.Item) bool {
 
 /* Market/Order Types */
 
+// Price 고정 소수점 가격 (거래소 기본 통화의 최소 단위 정수, 예: USD 12.34 -> 1234, KRW 1000 -> 1000)
+// 부동소수점 오차로 같은 가격의 호가가 나뉘지 않도록 호가, 체결, API 모두 정수로 주고받음
+type Price int64
+
 var (
 	OrderTypeLimit        = "limit"
 	OrderTypeMarket       = "market"
+	OrderTypeStop         = "stop"       // 발동 가격 도달 시 시장가 주문으로 전환
+	OrderTypeStopLimit    = "stop_limit" // 발동 가격 도달 시 지정가 주문으로 전환
 	SideBuy               = "buy"
 	SideSell              = "sell"
 	StatusOpen            = "open"
@@ -28,102 +34,354 @@ var (
 	StatusPartiallyFilled = "partially_filled"
 	StatusFilled          = "filled"
 	StatusCanceled        = "canceled"
+	StatusRejected        = "rejected"
 	StatusError           = "error"
 	Bids                  = "bids"
 	Asks                  = "asks"
-	MarketOrderIOC        = "IOC" // Immediate Or Cancel
-	MarketOrderFOK        = "FOK" // Fill Or Kill
+	Auction               = "auction" // 호가 WebSocket 의 예상 체결가 메시지 (AuctionIndicative)
+	MarketOrderIOC        = "IOC"     // Immediate Or Cancel
+	MarketOrderFOK        = "FOK"     // Fill Or Kill
+	ExecTypeNew           = "new"
+	ExecTypePartial       = "partial"
+	ExecTypeFill          = "fill"
+	ExecTypeCancel        = "cancel"
+	ExecTypeReject        = "reject"
+	ExecTypeReplace       = "replace"
+	ExecTypeTriggered     = "triggered" // 스탑 주문 발동
+	ExecTypeRestated      = "restated"  // 서버가 주문 수량을 줄임 (자기매매 방지 decrement)
+)
+
+// 주문 유효 기간 (Time In Force)
+var (
+	TimeInForceDay = "DAY" // 당일 장 종료 시 만료 (지정가 기본값)
+	TimeInForceGTC = "GTC" // Good Till Canceled, 취소할 때까지 유지 (다음 거래일에도 유지)
+	TimeInForceGTD = "GTD" // Good Till Date, expire_at 에 만료
+	TimeInForceIOC = "IOC" // Immediate Or Cancel, 즉시 체결되지 않은 수량은 취소 (시장가 기본값)
+	TimeInForceFOK = "FOK" // Fill Or Kill, 전량 즉시 체결되지 않으면 전부 취소
+)
+
+// 지정가 Post-Only (maker-only) 주문 처리 방식 (반대 호가와 겹치는 경우)
+var (
+	PostOnlyReject  = "reject"  // 주문 거절
+	PostOnlyReprice = "reprice" // 반대 최우선 호가에서 한 호가 단위 물러난 가격으로 조정
+)
+
+// 일괄 주문 (Batch) 개별 요청 종류
+var (
+	BatchActionNew    = "new"    // 신규 주문
+	BatchActionModify = "modify" // 주문 정정
+	BatchActionCancel = "cancel" // 주문 취소
+)
+
+// 자기매매 방지 (Self-Trade Prevention) 모드, 같은 사용자의 주문끼리 체결되려 하면 들어온 주문의 모드로 처리
+var (
+	STPNone         = "none"          // 사용하지 않음 (사용자 기본값 무시)
+	STPCancelNewest = "cancel_newest" // 들어온 주문의 남은 수량 취소
+	STPCancelOldest = "cancel_oldest" // 호가에 있던 주문 취소 후 계속 체결
+	STPCancelBoth   = "cancel_both"   // 두 주문 모두 취소
+	STPDecrement    = "decrement"     // 두 주문 모두 겹치는 수량만큼 줄임 (남은 수량이 없으면 취소)
+)
+
+// 단일가 매매 (call auction) 단계
+var (
+	AuctionOpening    = "opening"    // 프리장 동안 주문을 모아 정규장 시작 시 시가 결정
+	AuctionClosing    = "closing"    // 포스트장 동안 주문을 모아 장 종료 시 종가 결정
+	AuctionVolatility = "volatility" // 변동성 완화장치 발동 후 일정 시간 동안 주문을 모아 접속 매매 재개
+	AuctionHalt       = "halt"       // 거래소 전체 거래 중단 (서킷 브레이커), 재개 시 겹친 호가를 한 가격으로 체결
+)
+
+// 주문 거절 사유 코드
+var (
+	ReasonInvalidOrderID         = "invalid_order_id"
+	ReasonOrderNotOwned          = "order_not_owned"
+	ReasonInvalidOrderType       = "invalid_order_type"
+	ReasonInvalidPrice           = "invalid_price"
+	ReasonInvalidStopPrice       = "invalid_stop_price"
+	ReasonInvalidQuantity        = "invalid_quantity"
+	ReasonInvalidTimeInForce     = "invalid_time_in_force"
+	ReasonInvalidExpireTime      = "invalid_expire_time" // GTD 만료 시각이 없거나 이미 지남
+	ReasonInvalidPostOnly        = "invalid_post_only"
+	ReasonPostOnlyWouldCross     = "post_only_would_cross"  // Post-Only 주문이 즉시 체결됨
+	ReasonInvalidTickSize        = "invalid_tick_size"      // 가격이 호가 단위에 맞지 않음
+	ReasonBelowMinimumQuantity   = "below_minimum_quantity" // 최소 주문 수량 미만
+	ReasonPriceOutOfLimit        = "price_out_of_limit"     // 가격이 일일 상한가 / 하한가를 벗어남
+	ReasonAuctionInProgress      = "auction_in_progress"    // 단일가 매매 중에는 시장가, IOC, FOK 주문 불가
+	ReasonMarketHalted           = "market_halted"          // 거래소 전체 거래 중단 중에는 취소만 가능
+	ReasonInvalidSTP             = "invalid_self_trade_prevention"
+	ReasonSelfTradePrevention    = "self_trade_prevention"     // 자기매매 방지로 취소되거나 수량이 줄어듦
+	ReasonInvalidClientOrderID   = "invalid_client_order_id"   // 사용할 수 없는 문자가 있거나 너무 김
+	ReasonDuplicateClientOrderID = "duplicate_client_order_id" // 오늘 이미 사용한 client_order_id
+	ReasonNoChanges              = "no_changes"
+	ReasonInternalError          = "internal_error"
 )
 
 type OrderStatus struct {
-	OrderID   string  `json:"order_id"`
-	Side      string  `json:"side"` // "buy" or "sell"
-	OrderType string  `json:"type"` // e.g., "limit", "market"
-	Price     float64 `json:"price"`
-	Quantity  int     `json:"quantity"`
+	OrderID   string `json:"order_id"`
+	Side      string `json:"side"` // "buy" or "sell"
+	OrderType string `json:"type"` // e.g., "limit", "market"
+	Price     Price  `json:"price"`
+	Quantity  int    `json:"quantity"`
 }
 
 type OrderRequest struct {
-	Timestamp       int64       `json:"timestamp"` // on Server side, ignore client input
-	UserID          int         `json:"user_id"`   // on Server side, ignore client input
-	OrderID         string      `json:"order_id"`
-	Symbol          string      `json:"symbol"` // on Server side, ignore client input
-	Status          string      `json:"status"` // on Server side, ignore client input
-	Side            string      `json:"side"`   // on Server side, ignore client input
-	OrderType       string      `json:"type"`   // e.g., "limit", "market"
-	Price           float64     `json:"price"`
-	Quantity        int         `json:"quantity"`
-	Slippage        []float64   `json:"slippage,omitempty"`          // optional, for market orders [base_price, max_slippage_percent]
-	MarketOrderType string      `json:"market_order_type,omitempty"` // optional, for market orders IOC or FOK default is IOC
-	ResultChan      chan Result `json:"-"`                           // for server to send back result
+	Timestamp           int64       `json:"timestamp"` // on Server side, ignore client input
+	UserID              int         `json:"user_id"`   // on Server side, ignore client input
+	OrderID             string      `json:"order_id"`
+	ClientOrderID       string      `json:"client_order_id,omitempty"` // optional, 사용자별 하루 동안 고유한 주문 ID (정정, 취소 시 order_id 대신 사용 가능)
+	Symbol              string      `json:"symbol"`                    // on Server side, ignore client input
+	Status              string      `json:"status"`                    // on Server side, ignore client input
+	Side                string      `json:"side"`                      // on Server side, ignore client input
+	OrderType           string      `json:"type"`                      // e.g., "limit", "market", "stop", "stop_limit"
+	Price               Price       `json:"price"`
+	StopPrice           Price       `json:"stop_price,omitempty"` // optional, for stop / stop_limit orders
+	Quantity            int         `json:"quantity"`
+	Slippage            []float64   `json:"slippage,omitempty"`              // optional, for market orders [base_price(통화 단위, 예: USD 12.34), max_slippage_percent]
+	MarketOrderType     string      `json:"market_order_type,omitempty"`     // optional, for market orders IOC or FOK default is IOC
+	TimeInForce         string      `json:"time_in_force,omitempty"`         // optional, "DAY", "GTC", "GTD", "IOC", "FOK" (limit default DAY, market default IOC)
+	ExpireAt            int64       `json:"expire_at,omitempty"`             // optional, for GTD orders (unix milliseconds)
+	PostOnly            string      `json:"post_only,omitempty"`             // optional, for limit orders "reject" or "reprice"
+	DisplayQuantity     int         `json:"display_quantity,omitempty"`      // optional, for iceberg limit orders (호가에 노출되는 수량)
+	SelfTradePrevention string      `json:"self_trade_prevention,omitempty"` // optional, "none", "cancel_newest", "cancel_oldest", "cancel_both", "decrement" (생략 시 사용자 기본값)
+	SlippagePrice       Price       `json:"-"`                               // on Server side, slippage 기준 가격(통화 단위)을 최소 단위로 변환한 값
+	CancelReason        string      `json:"-"`                               // on Server side, 만료 등 서버가 취소하는 경우의 취소 사유
+	ResultChan          chan Result `json:"-"`                               // for server to send back result
 }
 
 type Result struct {
-	Timestamp int64  `json:"timestamp"`
-	Success   bool   `json:"success"`
-	Message   string `json:"message"`
-	Code      int    `json:"code"`
+	Timestamp  int64  `json:"timestamp"`
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	Code       int    `json:"code"`
+	ReasonCode string `json:"reason_code,omitempty"` // 거절 사유 코드 (Reason*)
 }
 
 type UpdateDepth struct {
-	Timestamp int64   `json:"timestamp"` // on Server side, ignore client input
-	Symbol    string  `json:"symbol"`
-	Side      string  `json:"side"` // "bids" or "asks"
-	Price     float64 `json:"price"`
-	Quantity  int     `json:"quantity"`
+	Timestamp int64  `json:"timestamp"` // on Server side, ignore client input
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"` // "bids" or "asks"
+	Price     Price  `json:"price"`
+	Quantity  int    `json:"quantity"`
+}
+
+// AuctionIndicative 단일가 매매 중 예상 체결가 (호가 WebSocket 으로 전송, side 가 "auction" 인 메시지)
+type AuctionIndicative struct {
+	Timestamp int64  `json:"timestamp"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`      // "auction"
+	Phase     string `json:"phase"`     // "opening", "closing" or "volatility"
+	Price     Price  `json:"price"`     // 예상 체결가 (체결 가능한 가격이 없으면 0)
+	Quantity  int    `json:"quantity"`  // 예상 체결 수량
+	Imbalance int    `json:"imbalance"` // 예상 체결가에서 체결되지 못하는 수량 (매수 잔량 +, 매도 잔량 -)
+}
+
+type OpenOrder struct {
+	OrderID             string  `json:"order_id"`
+	ClientOrderID       string  `json:"client_order_id,omitempty"`
+	Symbol              string  `json:"symbol"`
+	Side                string  `json:"side"` // "buy" or "sell"
+	OrderType           string  `json:"type"` // 호가에 남는 주문은 "limit", 발동 대기 중인 주문은 "stop" or "stop_limit"
+	Price               Price   `json:"price"`
+	StopPrice           Price   `json:"stop_price,omitempty"` // 발동 대기 중인 스탑 주문만
+	TimeInForce         string  `json:"time_in_force"`
+	ExpireAt            int64   `json:"expire_at,omitempty"`        // GTD 주문만
+	PostOnly            string  `json:"post_only,omitempty"`        // "reject" or "reprice"
+	DisplayQuantity     int     `json:"display_quantity,omitempty"` // 아이스버그 주문만 (remaining_quantity 는 숨겨진 수량 포함)
+	SelfTradePrevention string  `json:"self_trade_prevention,omitempty"`
+	OriginalQuantity    int     `json:"original_quantity"`
+	RemainingQuantity   int     `json:"remaining_quantity"`
+	AvgPrice            float64 `json:"avg_price"` // Price 단위 (소수점 포함)
+	Status              string  `json:"status"`    // "open" or "partially_filled"
+	CreatedAt           int64   `json:"created_at"`
+	UpdatedAt           int64   `json:"updated_at"`
 }
 
 // Template Only Structs Below
 
 type CreateOrderRequest struct {
-	OrderType string  `json:"type"` // e.g., "limit", "market"
-	Price     float64 `json:"price"`
-	Quantity  int     `json:"quantity"`
+	ClientOrderID       string `json:"client_order_id,omitempty"` // 사용자별 하루 동안 고유한 주문 ID (최대 64자, 영문, 숫자, - _ . : 만 가능)
+	OrderType           string `json:"type"`                      // e.g., "limit", "market", "stop", "stop_limit"
+	Price               Price  `json:"price"`
+	StopPrice           Price  `json:"stop_price,omitempty"` // "stop", "stop_limit" 만
+	Quantity            int    `json:"quantity"`
+	TimeInForce         string `json:"time_in_force,omitempty"`         // "DAY", "GTC", "GTD", "IOC", "FOK" (지정가 기본값 DAY, 시장가 기본값 IOC)
+	ExpireAt            int64  `json:"expire_at,omitempty"`             // GTD 만 (unix milliseconds)
+	PostOnly            string `json:"post_only,omitempty"`             // "limit" 만, 반대 호가와 겹치면 "reject" 거절, "reprice" 한 호가 물러난 가격으로 조정
+	DisplayQuantity     int    `json:"display_quantity,omitempty"`      // "limit" 만, 아이스버그 주문의 호가 노출 수량 (나머지는 숨겨진 수량으로 체결될 때마다 다시 채움)
+	SelfTradePrevention string `json:"self_trade_prevention,omitempty"` // 내 주문끼리 체결되려 할 때 "cancel_newest", "cancel_oldest", "cancel_both", "decrement", "none" (생략 시 사용자 기본값)
 }
 
 type ModifyOrderRequest struct {
-	OrderID   string  `json:"order_id"`
-	OrderType string  `json:"type"` // e.g., "limit", "market"
-	Price     float64 `json:"price"`
-	Quantity  int     `json:"quantity"`
+	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id,omitempty"` // order_id 대신 사용 가능
+	OrderType     string `json:"type"`                      // e.g., "limit", "market" (발동 대기 중인 스탑 주문은 "stop", "stop_limit")
+	Price         Price  `json:"price"`
+	StopPrice     Price  `json:"stop_price,omitempty"` // 발동 대기 중인 스탑 주문만 (0이면 기존 값 유지)
+	Quantity      int    `json:"quantity"`
 }
 
 type CancelOrderRequest struct {
-	OrderID string `json:"order_id"`
+	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id,omitempty"` // order_id 대신 사용 가능
+}
+
+// BatchOrderItem 일괄 주문의 개별 요청 (action 에 따라 CreateOrderRequest, ModifyOrderRequest, CancelOrderRequest 의 필드 사용)
+type BatchOrderItem struct {
+	Action              string `json:"action"` // "new", "modify" or "cancel"
+	Symbol              string `json:"symbol"`
+	Side                string `json:"side"`                      // "buy" or "sell"
+	OrderID             string `json:"order_id,omitempty"`        // "modify", "cancel" 만
+	ClientOrderID       string `json:"client_order_id,omitempty"` // "new" 는 새 ID, "modify", "cancel" 은 order_id 대신 사용 가능
+	OrderType           string `json:"type,omitempty"`
+	Price               Price  `json:"price,omitempty"`
+	StopPrice           Price  `json:"stop_price,omitempty"`
+	Quantity            int    `json:"quantity,omitempty"`
+	TimeInForce         string `json:"time_in_force,omitempty"`
+	ExpireAt            int64  `json:"expire_at,omitempty"`
+	PostOnly            string `json:"post_only,omitempty"`
+	DisplayQuantity     int    `json:"display_quantity,omitempty"`
+	SelfTradePrevention string `json:"self_trade_prevention,omitempty"`
+}
+
+type BatchOrderRequest struct {
+	Orders []BatchOrderItem `json:"orders"`
+}
+
+// BatchOrderResult 일괄 주문의 개별 처리 결과 (요청 순서와 같음)
+type BatchOrderResult struct {
+	Index         int    `json:"index"`
+	Action        string `json:"action"`
+	Symbol        string `json:"symbol"`
+	Side          string `json:"side"`
+	Success       bool   `json:"success"`
+	Code          int    `json:"code"`
+	OrderID       string `json:"order_id,omitempty"`
+	ClientOrderID string `json:"client_order_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+	ReasonCode    string `json:"reason_code,omitempty"`
+}
+
+/* Trade WebSocket */
+
+// 거래 WebSocket 응답 종류 (실행 보고서는 event 없이 ExecutionReport 그대로 전송)
+var (
+	TradeEventAck    = "ack"    // 매칭 엔진에서 요청 처리 완료
+	TradeEventReject = "reject" // 요청 거절
+)
+
+// TradeRequest 거래 WebSocket 주문 요청 (id 는 응답에 그대로 돌려줌)
+type TradeRequest struct {
+	RequestID string `json:"id"`
+	BatchOrderItem
+}
+
+// TradeResponse 거래 WebSocket 요청 처리 결과
+type TradeResponse struct {
+	Event         string `json:"event"` // "ack" or "reject"
+	RequestID     string `json:"id"`
+	Timestamp     int64  `json:"timestamp"`
+	Action        string `json:"action"`
+	Symbol        string `json:"symbol"`
+	Side          string `json:"side"`
+	OrderID       string `json:"order_id,omitempty"`
+	ClientOrderID string `json:"client_order_id,omitempty"`
+	Code          int    `json:"code"`
+	Error         string `json:"error,omitempty"`
+	ReasonCode    string `json:"reason_code,omitempty"`
 }
 
 /* Depth WebSocket */
 
 type Order struct {
-	UserID   int `json:"userID"`
-	Quantity int `json:"quantity"`
+	UserID    int   `json:"userID"`
+	Quantity  int   `json:"quantity"`
+	CreatedAt int64 `json:"createdAt"`
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// OrderExecution 주문별 누적 체결 상태 (호가에서 빠진 뒤에도 주문이 끝날 때까지 유지)
+type OrderExecution struct {
+	UserID              int
+	ClientOrderID       string
+	Side                string
+	OrderType           string
+	Price               Price
+	StopPrice           Price
+	TimeInForce         string
+	ExpireAt            int64 // GTD 만료 시각 (unix milliseconds)
+	PostOnly            string
+	DisplayQuantity     int    // 아이스버그 주문의 노출 수량 (0이면 전체 노출)
+	SelfTradePrevention string // 자기매매 방지 모드 (빈 값이면 사용 안함)
+	Quantity            int    // 주문(정정) 수량
+	CumQuantity         int    // 누적 체결 수량
+	CumAmount           int64  // 누적 체결 금액 (평균 체결가 계산용, Price 단위)
 }
 
 type MarketDepth struct {
-	Bids      map[float64]map[string]Order `json:"bids"`
-	Asks      map[float64]map[string]Order `json:"asks"`
-	TotalBids map[float64]int              `json:"totalBids"`
-	TotalAsks map[float64]int              `json:"totalAsks"`
-	BidTree   *btree.BTree                 `json:"bidTree"`
-	AskTree   *btree.BTree                 `json:"askTree"`
+	Bids      map[Price]map[string]Order `json:"bids"`
+	Asks      map[Price]map[string]Order `json:"asks"`
+	TotalBids map[Price]int              `json:"totalBids"`
+	TotalAsks map[Price]int              `json:"totalAsks"`
+	BidTree   *btree.BTree               `json:"bidTree"`
+	AskTree   *btree.BTree               `json:"askTree"`
+
+	Executions map[string]*OrderExecution `json:"-"` // 주문 ID별 체결 상태
+	LastPrice  Price                      `json:"-"` // 최종 체결가 (스탑 주문 발동 기준)
+
+	PriceBand   [2]Price `json:"-"` // 변동성 완화장치 체결 가능 범위 [하한, 상한] (0이면 제한 없음)
+	Interrupted bool     `json:"-"` // 체결 가격이 범위를 벗어나 체결이 중단됨 (변동성 완화장치 발동)
 }
 
 /* Ledger WebSocket */
 
 type Ledger struct {
-	Timestamp   int64   `json:"timestamp"`
-	Symbol      string  `json:"symbol"`
-	Price       float64 `json:"price"`
-	Volume      int     `json:"volume"`
-	Side        string  `json:"side"` // "buy" or "sell"
-	ExecutionID string  `json:"execution_id"`
-	BuyOrderID  string  `json:"buy_order_id"`
-	SellOrderID string  `json:"sell_order_id"`
-	Conditions  string  `json:"conditions"`
+	Timestamp   int64  `json:"timestamp"`
+	Symbol      string `json:"symbol"`
+	Price       Price  `json:"price"`
+	Volume      int    `json:"volume"`
+	Side        string `json:"side"` // 체결을 일으킨 쪽 "buy" or "sell" (단일가 매매 체결은 "")
+	ExecutionID string `json:"execution_id"`
+	BuyOrderID  string `json:"buy_order_id"`
+	SellOrderID string `json:"sell_order_id"`
+	BuyerID     int    `json:"-"`          // 서버 내부용 (외부에 공개하지 않음)
+	SellerID    int    `json:"-"`          // 서버 내부용 (외부에 공개하지 않음)
+	Conditions  string `json:"conditions"` // pr: 프리장, re: 정규장, po: 포스트장, oa: 시가 단일가, ca: 종가 단일가, va: 변동성 완화장치 단일가, ha: 거래 재개 단일가
+}
+
+/* Notify WebSocket */
+
+// ExecutionReport 주문 상태 변경 / 체결 알림
+type ExecutionReport struct {
+	Seq             int64   `json:"seq"` // 사용자별로 1씩 증가 (누락 확인용)
+	Timestamp       int64   `json:"timestamp"`
+	UserID          int     `json:"-"`
+	ExecType        string  `json:"exec_type"`              // "new", "partial", "fill", "cancel", "reject", "replace"
+	ExecutionID     string  `json:"execution_id,omitempty"` // 체결인 경우 Ledger.ExecutionID 와 동일
+	OrderID         string  `json:"order_id"`
+	ClientOrderID   string  `json:"client_order_id,omitempty"`
+	Symbol          string  `json:"symbol"`
+	Side            string  `json:"side"` // "buy" or "sell"
+	OrderType       string  `json:"type"` // "limit" or "market"
+	Status          string  `json:"status"`
+	Price           Price   `json:"price"`                // 주문 가격
+	StopPrice       Price   `json:"stop_price,omitempty"` // 스탑 주문 발동 가격
+	TimeInForce     string  `json:"time_in_force,omitempty"`
+	ExpireAt        int64   `json:"expire_at,omitempty"` // GTD 만
+	PostOnly        string  `json:"post_only,omitempty"`
+	DisplayQuantity int     `json:"display_quantity,omitempty"`
+	Quantity        int     `json:"quantity"` // 주문(정정) 수량
+	LastPrice       Price   `json:"last_price"`
+	LastQuantity    int     `json:"last_quantity"`
+	CumQuantity     int     `json:"cum_quantity"`
+	LeavesQuantity  int     `json:"leaves_quantity"`
+	AvgPrice        float64 `json:"avg_price"`             // Price 단위 (소수점 포함)
+	Reason          string  `json:"reason,omitempty"`      // 거절, 취소 사유
+	ReasonCode      string  `json:"reason_code,omitempty"` // 거절 사유 코드 (Reason*)
 }
 
 /* Session WebSocket */
 
 type SessionStatus struct {
-	Session string `json:"session"`
+	Session  string `json:"session"`
+	Reason   string `json:"reason,omitempty"`    // 거래 중단 사유 (halt)
+	ResumeAt int64  `json:"resume_at,omitempty"` // 거래 재개 예정 시각 (halt, resume-5m, resume-1m)
 }
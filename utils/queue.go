@@ -68,6 +68,14 @@ func (q *Queue[T]) DeepClone() *Queue[T] {
 	return newQueue
 }
 
+func (q *Queue[T]) Values() []T {
+	values := make([]T, 0, q.items.Len())
+	for e := q.items.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value.(T))
+	}
+	return values
+}
+
 func (q *Queue[T]) IsEmpty() bool {
 	return q.items == nil || q.items.Len() == 0
 }